@@ -0,0 +1,666 @@
+package litearray
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Array is an n-dimensional array of float64 values. An Array is described by its shape,
+// its strides and an offset into a backing buffer that may be shared with other arrays, so
+// Reshape, Transpose and Slice can return views without copying the data.
+type Array struct {
+	data    []float64
+	shape   []int
+	strides []int
+	offset  int
+}
+
+// Range selects the half-open interval [Start, Stop) of an axis, taking every Step-th element.
+// Negative Start and Stop values count from the end of the axis, a Stop past the end is
+// clamped to the axis length, and a Step of zero is treated as one.
+type Range struct {
+	Start, Stop, Step int
+}
+
+// WholeAxis is the Range that selects every element of an axis.
+var WholeAxis = Range{Start: 0, Stop: math.MaxInt, Step: 1}
+
+// NewArray wraps data in an array of the given shape without copying it. When no shape is
+// given the array is one-dimensional with len(data) elements.
+func NewArray(data []float64, shape ...int) (*Array, error) {
+	// Default to a one-dimensional array covering the whole buffer
+	if len(shape) == 0 {
+		shape = []int{len(data)}
+	}
+
+	// Check that the shape is valid and matches the amount of data
+	size, err := shapeSize(shape)
+	if err != nil {
+		return nil, err
+	}
+	if size != len(data) {
+		return nil, shapeError("NewArray", [][]int{shape}, "cannot create array of shape %v from %d elements", shape, len(data))
+	}
+
+	return &Array{
+		data:    data,
+		shape:   append([]int(nil), shape...),
+		strides: contiguousStrides(shape),
+	}, nil
+}
+
+// NewZeroArray creates a new array of the given shape with every element set to zero.
+func NewZeroArray(shape ...int) (*Array, error) {
+	size, err := shapeSize(shape)
+	if err != nil {
+		return nil, err
+	}
+	return NewArray(make([]float64, size), shape...)
+}
+
+// NewArrayFromMatrix copies a 2D matrix into a new two-dimensional array.
+func NewArrayFromMatrix(matrix [][]float64) (*Array, error) {
+	// Check if the matrix is empty
+	if len(matrix) == 0 {
+		return nil, emptyError("NewArrayFromMatrix", matrixShapes(matrix), "matrix cannot be empty")
+	}
+
+	// Check if the matrix is a valid 2D slice
+	width := len(matrix[0])
+	for _, row := range matrix {
+		if len(row) != width {
+			return nil, shapeError("NewArrayFromMatrix", matrixShapes(matrix), "all rows in the matrix must have the same length")
+		}
+	}
+
+	// Copy the rows into a single row-major buffer
+	data := make([]float64, 0, len(matrix)*width)
+	for _, row := range matrix {
+		data = append(data, row...)
+	}
+
+	return NewArray(data, len(matrix), width)
+}
+
+// NewArrayFromDense creates a two-dimensional array that shares its backing buffer with a Gonum Dense matrix.
+func NewArrayFromDense(m *mat.Dense) *Array {
+	raw := m.RawMatrix()
+	return &Array{
+		data:    raw.Data,
+		shape:   []int{raw.Rows, raw.Cols},
+		strides: []int{raw.Stride, 1},
+	}
+}
+
+// Shape returns the length of each axis of the array.
+func (a *Array) Shape() []int {
+	return append([]int(nil), a.shape...)
+}
+
+// Strides returns the distance in elements between consecutive entries along each axis.
+func (a *Array) Strides() []int {
+	return append([]int(nil), a.strides...)
+}
+
+// Ndim returns the number of axes of the array.
+func (a *Array) Ndim() int {
+	return len(a.shape)
+}
+
+// Size returns the total number of elements in the array.
+func (a *Array) Size() int {
+	size := 1
+	for _, dim := range a.shape {
+		size *= dim
+	}
+	return size
+}
+
+// IsContiguous reports whether the elements of the array are laid out in row-major order without gaps.
+func (a *Array) IsContiguous() bool {
+	expected := 1
+	for axis := len(a.shape) - 1; axis >= 0; axis-- {
+		// Axes of length one can have any stride
+		if a.shape[axis] != 1 && a.strides[axis] != expected {
+			return false
+		}
+		expected *= a.shape[axis]
+	}
+	return true
+}
+
+// At returns the element at the given index, which must have one entry per axis.
+func (a *Array) At(index ...int) (float64, error) {
+	off, err := a.elementOffset(index)
+	if err != nil {
+		return 0, err
+	}
+	return a.data[off], nil
+}
+
+// Set stores value at the given index. Views share their backing buffer, so the change is visible through every view of the same data.
+func (a *Array) Set(value float64, index ...int) error {
+	off, err := a.elementOffset(index)
+	if err != nil {
+		return err
+	}
+	a.data[off] = value
+	return nil
+}
+
+// Data returns a copy of the elements of the array in row-major order.
+func (a *Array) Data() []float64 {
+	result := make([]float64, a.Size())
+	a.each(func(pos, off int) {
+		result[pos] = a.data[off]
+	})
+	return result
+}
+
+// Copy returns a contiguous copy of the array that does not share memory with the original.
+func (a *Array) Copy() *Array {
+	return &Array{
+		data:    a.Data(),
+		shape:   a.Shape(),
+		strides: contiguousStrides(a.shape),
+	}
+}
+
+// Reshape returns an array with the same elements and a new shape. At most one entry of the
+// shape may be -1, in which case its length is inferred from the others. The result is a view
+// when the array is contiguous and a copy otherwise.
+func (a *Array) Reshape(shape ...int) (*Array, error) {
+	size := a.Size()
+	shape = append([]int(nil), shape...)
+
+	// Infer the length of a single -1 axis from the remaining ones
+	inferred := -1
+	known := 1
+	for axis, dim := range shape {
+		switch {
+		case dim == -1 && inferred == -1:
+			inferred = axis
+		case dim == -1:
+			return nil, argumentError("Array.Reshape", "can only specify one unknown dimension")
+		case dim < 0:
+			return nil, argumentError("Array.Reshape", "negative dimension %d in shape %v", dim, shape)
+		default:
+			known *= dim
+		}
+	}
+	if inferred >= 0 && known != 0 && size%known == 0 {
+		shape[inferred] = size / known
+		known = size
+	}
+
+	// The new shape must describe exactly as many elements as the array holds
+	if known != size || inferred >= 0 && shape[inferred] == -1 {
+		return nil, shapeError("Array.Reshape", [][]int{a.shape, shape}, "cannot reshape array of size %d into shape %v", size, shape)
+	}
+
+	// Views are only possible when the elements are already in row-major order
+	source := a
+	if !a.IsContiguous() {
+		source = a.Copy()
+	}
+
+	return &Array{
+		data:    source.data,
+		shape:   shape,
+		strides: contiguousStrides(shape),
+		offset:  source.offset,
+	}, nil
+}
+
+// Transpose returns a view of the array with its axes permuted. With no arguments the axes
+// are reversed, which for a two-dimensional array is the usual matrix transpose.
+func (a *Array) Transpose(axes ...int) (*Array, error) {
+	ndim := len(a.shape)
+
+	// Default to reversing the order of the axes
+	if len(axes) == 0 {
+		axes = make([]int, ndim)
+		for i := range axes {
+			axes[i] = ndim - 1 - i
+		}
+	}
+
+	// Check that the axes form a permutation
+	if len(axes) != ndim {
+		return nil, argumentError("Array.Transpose", "axes don't match array: got %d axes for %d-dimensional array", len(axes), ndim)
+	}
+	seen := make([]bool, ndim)
+	for _, axis := range axes {
+		if axis < 0 || axis >= ndim || seen[axis] {
+			return nil, argumentError("Array.Transpose", "axes %v are not a permutation of the array's axes", axes)
+		}
+		seen[axis] = true
+	}
+
+	// Permute the shape and strides without touching the data
+	shape := make([]int, ndim)
+	strides := make([]int, ndim)
+	for i, axis := range axes {
+		shape[i] = a.shape[axis]
+		strides[i] = a.strides[axis]
+	}
+
+	return &Array{data: a.data, shape: shape, strides: strides, offset: a.offset}, nil
+}
+
+// Slice returns a view of the array restricted to the given ranges. The ranges apply to the
+// leading axes in order; axes without a range are kept whole.
+func (a *Array) Slice(ranges ...Range) (*Array, error) {
+	if len(ranges) > len(a.shape) {
+		return nil, argumentError("Array.Slice", "too many ranges: got %d for %d-dimensional array", len(ranges), len(a.shape))
+	}
+
+	shape := a.Shape()
+	strides := a.Strides()
+	offset := a.offset
+	for axis, r := range ranges {
+		dim := a.shape[axis]
+
+		// Normalise the step and the bounds of the range
+		step := r.Step
+		if step == 0 {
+			step = 1
+		}
+		if step < 0 {
+			return nil, argumentError("Array.Slice", "step must be positive, got %d on axis %d", step, axis)
+		}
+		start := clampIndex(r.Start, dim)
+		stop := clampIndex(r.Stop, dim)
+
+		// Count the elements selected on this axis
+		count := 0
+		if stop > start {
+			count = (stop - start + step - 1) / step
+		}
+
+		if count > 0 {
+			offset += start * a.strides[axis]
+		}
+		shape[axis] = count
+		strides[axis] = a.strides[axis] * step
+	}
+
+	return &Array{data: a.data, shape: shape, strides: strides, offset: offset}, nil
+}
+
+// ToMatrix copies a two-dimensional array into a 2D slice.
+func (a *Array) ToMatrix() ([][]float64, error) {
+	if len(a.shape) != 2 {
+		return nil, shapeError("Array.ToMatrix", [][]int{a.shape}, "array must be two-dimensional, got shape %v", a.shape)
+	}
+
+	data := a.Data()
+	matrix := make([][]float64, a.shape[0])
+	for i := range matrix {
+		matrix[i] = data[i*a.shape[1] : (i+1)*a.shape[1] : (i+1)*a.shape[1]]
+	}
+	return matrix, nil
+}
+
+// ToDense copies a two-dimensional array into a new Gonum Dense matrix.
+func (a *Array) ToDense() (*mat.Dense, error) {
+	if len(a.shape) != 2 || a.Size() == 0 {
+		return nil, shapeError("Array.ToDense", [][]int{a.shape}, "array must be two-dimensional and non-empty, got shape %v", a.shape)
+	}
+	return mat.NewDense(a.shape[0], a.shape[1], a.Data()), nil
+}
+
+// String formats the array as nested brackets, one level per axis.
+func (a *Array) String() string {
+	var b strings.Builder
+	data := a.Data()
+	var write func(axis, start int)
+	write = func(axis, start int) {
+		// Zero-dimensional arrays hold a single scalar
+		if axis == len(a.shape) {
+			fmt.Fprint(&b, data[start])
+			return
+		}
+		block := 1
+		for _, dim := range a.shape[axis+1:] {
+			block *= dim
+		}
+		b.WriteByte('[')
+		for i := 0; i < a.shape[axis]; i++ {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			write(axis+1, start+i*block)
+		}
+		b.WriteByte(']')
+	}
+	write(0, 0)
+	return b.String()
+}
+
+// Add adds the array and others element-wise, like AddArrays. The arrays are broadcast together.
+// It is equivalent to AddWith(others, WithPrecision(precision)).
+func (a *Array) Add(precision int, others ...*Array) (*Array, error) {
+	return a.AddWith(others, WithPrecision(precision))
+}
+
+// AddWith adds the array and others element-wise, like Add. The arrays are broadcast together.
+func (a *Array) AddWith(others []*Array, opts ...Option) (*Array, error) {
+	return a.elementwise(others, func(data [][]float64) ([]float64, error) {
+		return Add(data, opts...)
+	})
+}
+
+// Subtract subtracts others from the array element-wise, like SubtractArrays. The arrays are broadcast together.
+// It is equivalent to SubtractWith(others, WithPrecision(precision)).
+func (a *Array) Subtract(precision int, others ...*Array) (*Array, error) {
+	return a.SubtractWith(others, WithPrecision(precision))
+}
+
+// SubtractWith subtracts others from the array element-wise, like Subtract. The arrays are broadcast together.
+func (a *Array) SubtractWith(others []*Array, opts ...Option) (*Array, error) {
+	return a.elementwise(others, func(data [][]float64) ([]float64, error) {
+		return Subtract(data, opts...)
+	})
+}
+
+// Multiply multiplies the array and others element-wise, like MultiplyArrays. The arrays are broadcast together.
+// It is equivalent to MultiplyWith(others, WithPrecision(precision)).
+func (a *Array) Multiply(precision int, others ...*Array) (*Array, error) {
+	return a.MultiplyWith(others, WithPrecision(precision))
+}
+
+// MultiplyWith multiplies the array and others element-wise, like Multiply. The arrays are broadcast together.
+func (a *Array) MultiplyWith(others []*Array, opts ...Option) (*Array, error) {
+	return a.elementwise(others, func(data [][]float64) ([]float64, error) {
+		return Multiply(data, opts...)
+	})
+}
+
+// Divide divides the array by others element-wise, like DivideArrays. The arrays are broadcast together.
+// It is equivalent to DivideWith(others, WithPrecision(precision)).
+func (a *Array) Divide(precision int, others ...*Array) (*Array, error) {
+	return a.DivideWith(others, WithPrecision(precision))
+}
+
+// DivideWith divides the array by others element-wise, like Divide. The arrays are broadcast together.
+func (a *Array) DivideWith(others []*Array, opts ...Option) (*Array, error) {
+	return a.elementwise(others, func(data [][]float64) ([]float64, error) {
+		return Divide(data, opts...)
+	})
+}
+
+// Power raises each element of the array to the corresponding element of exponent, like PowerArrays.
+// It is equivalent to PowerWith(exponent, WithPrecision(precision)).
+func (a *Array) Power(precision int, exponent *Array) (*Array, error) {
+	return a.PowerWith(exponent, WithPrecision(precision))
+}
+
+// PowerWith raises each element of the array to the corresponding element of exponent, like Power.
+func (a *Array) PowerWith(exponent *Array, opts ...Option) (*Array, error) {
+	return a.elementwise([]*Array{exponent}, func(data [][]float64) ([]float64, error) {
+		return Power(data[0], data[1], opts...)
+	})
+}
+
+// Mod calculates the remainder of dividing the array by divisor element-wise, like ModuloArrays.
+// It is equivalent to ModWith(divisor, WithPrecision(precision)).
+func (a *Array) Mod(precision int, divisor *Array) (*Array, error) {
+	return a.ModWith(divisor, WithPrecision(precision))
+}
+
+// ModWith calculates the remainder of dividing the array by divisor element-wise, like Modulo.
+func (a *Array) ModWith(divisor *Array, opts ...Option) (*Array, error) {
+	return a.elementwise([]*Array{divisor}, func(data [][]float64) ([]float64, error) {
+		return Modulo(data[0], data[1], opts...)
+	})
+}
+
+// Log calculates the logarithm of each element of the array with respect to the corresponding element of base, like LogArrays.
+// It is equivalent to LogWith(base, WithPrecision(precision)).
+func (a *Array) Log(precision int, base *Array) (*Array, error) {
+	return a.LogWith(base, WithPrecision(precision))
+}
+
+// LogWith calculates the logarithm of each element of the array with respect to the corresponding element of base, like Log.
+func (a *Array) LogWith(base *Array, opts ...Option) (*Array, error) {
+	return a.elementwise([]*Array{base}, func(data [][]float64) ([]float64, error) {
+		return Log(data[1], data[0], opts...)
+	})
+}
+
+// Sqrt calculates the square root of the element-wise sum of the array and others, like SqrtArrays.
+// It is equivalent to SqrtWith(others, WithPrecision(precision)).
+func (a *Array) Sqrt(precision int, others ...*Array) (*Array, error) {
+	return a.SqrtWith(others, WithPrecision(precision))
+}
+
+// SqrtWith calculates the square root of the element-wise sum of the array and others, like Sqrt.
+func (a *Array) SqrtWith(others []*Array, opts ...Option) (*Array, error) {
+	return a.elementwise(others, func(data [][]float64) ([]float64, error) {
+		// Sqrt needs two operands, and adding zeros leaves a lone array unchanged
+		if len(data) == 1 {
+			data = append(data, make([]float64, len(data[0])))
+		}
+		return Sqrt(data, opts...)
+	})
+}
+
+// Abs calculates the absolute value of the element-wise sum of the array and others, like AbsArrays.
+// It is equivalent to AbsWith(others, WithPrecision(precision)).
+func (a *Array) Abs(precision int, others ...*Array) (*Array, error) {
+	return a.AbsWith(others, WithPrecision(precision))
+}
+
+// AbsWith calculates the absolute value of the element-wise sum of the array and others, like Abs.
+func (a *Array) AbsWith(others []*Array, opts ...Option) (*Array, error) {
+	return a.elementwise(others, func(data [][]float64) ([]float64, error) {
+		// Abs needs two operands, and adding zeros leaves a lone array unchanged
+		if len(data) == 1 {
+			data = append(data, make([]float64, len(data[0])))
+		}
+		return Abs(data, opts...)
+	})
+}
+
+// Mode calculates the mode(s) of all elements of the array, like ModeMultipleArrays.
+// It is equivalent to ModeWith(WithPrecision(precision)).
+func (a *Array) Mode(precision int) (*Array, error) {
+	return a.ModeWith(WithPrecision(precision))
+}
+
+// ModeWith calculates the mode(s) of all elements of the array, like Mode.
+func (a *Array) ModeWith(opts ...Option) (*Array, error) {
+	modes, err := Mode([][]float64{a.Data()}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewArray(modes)
+}
+
+// TransposeMatrix transposes a two-dimensional array into a new contiguous array, like TransposeMatrix.
+// It is equivalent to TransposeMatrixWith(WithPrecision(precision)).
+func (a *Array) TransposeMatrix(precision int) (*Array, error) {
+	return a.TransposeMatrixWith(WithPrecision(precision))
+}
+
+// TransposeMatrixWith transposes a two-dimensional array into a new contiguous array, like Transpose.
+func (a *Array) TransposeMatrixWith(opts ...Option) (*Array, error) {
+	matrix, err := a.ToMatrix()
+	if err != nil {
+		return nil, err
+	}
+	transposed, err := Transpose(matrix, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewArrayFromMatrix(transposed)
+}
+
+// Determinant calculates the determinant of a two-dimensional square array, like DeterminantMatrix.
+func (a *Array) Determinant() (float64, error) {
+	matrix, err := a.ToMatrix()
+	if err != nil {
+		return 0, err
+	}
+	return DeterminantMatrix(matrix)
+}
+
+// LogDeterminant calculates the sign and log-absolute determinant of a two-dimensional square
+// array, like LogDeterminantMatrix.
+func (a *Array) LogDeterminant() (sign, logAbsDet float64, err error) {
+	matrix, err := a.ToMatrix()
+	if err != nil {
+		return 0, 0, err
+	}
+	return LogDeterminantMatrix(matrix)
+}
+
+// Inverse calculates the inverse of a two-dimensional square array, like InversionMatrix.
+func (a *Array) Inverse() (*Array, error) {
+	matrix, err := a.ToMatrix()
+	if err != nil {
+		return nil, err
+	}
+	inverse, err := InversionMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+	return NewArrayFromMatrix(inverse)
+}
+
+// Eigenvalues computes the eigenvalues of a two-dimensional square array, like Eigenvalues3x3AndHigher.
+func (a *Array) Eigenvalues() ([]complex128, error) {
+	matrix, err := a.ToMatrix()
+	if err != nil {
+		return nil, err
+	}
+	return Eigenvalues3x3AndHigher(matrix)
+}
+
+// elementwise broadcasts a and others to a common shape, passes their row-major data to fn
+// and wraps the result in a new array of that shape.
+func (a *Array) elementwise(others []*Array, fn func(data [][]float64) ([]float64, error)) (*Array, error) {
+	// Work out the common shape of all operands
+	arrays := append([]*Array{a}, others...)
+	shapes := make([][]int, len(arrays))
+	for i, array := range arrays {
+		if array == nil {
+			return nil, emptyError("Array.elementwise", nil, "arrays cannot be nil")
+		}
+		shapes[i] = array.shape
+	}
+	shape, err := BroadcastShapes(shapes...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expand every operand to the common shape
+	data := make([][]float64, len(arrays))
+	for i, array := range arrays {
+		view, err := array.BroadcastTo(shape...)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = view.Data()
+	}
+
+	result, err := fn(data)
+	if err != nil {
+		return nil, err
+	}
+	return wrapArray(result, shape), nil
+}
+
+// each calls fn with the row-major position and the buffer offset of every element of a.
+func (a *Array) each(fn func(pos, off int)) {
+	size := a.Size()
+	if size == 0 {
+		return
+	}
+
+	index := make([]int, len(a.shape))
+	off := a.offset
+	for pos := 0; pos < size; pos++ {
+		fn(pos, off)
+
+		// Advance the index like an odometer, with the last axis moving fastest
+		for axis := len(a.shape) - 1; axis >= 0; axis-- {
+			index[axis]++
+			off += a.strides[axis]
+			if index[axis] < a.shape[axis] {
+				break
+			}
+			off -= a.strides[axis] * a.shape[axis]
+			index[axis] = 0
+		}
+	}
+}
+
+// elementOffset converts an index into an offset in the backing buffer.
+func (a *Array) elementOffset(index []int) (int, error) {
+	if len(index) != len(a.shape) {
+		return 0, argumentError("Array.At", "index %v has %d entries for %d-dimensional array", index, len(index), len(a.shape))
+	}
+	off := a.offset
+	for axis, i := range index {
+		if i < 0 || i >= a.shape[axis] {
+			return 0, argumentError("Array.At", "index %d is out of bounds for axis %d with size %d", i, axis, a.shape[axis])
+		}
+		off += i * a.strides[axis]
+	}
+	return off, nil
+}
+
+// wrapArray wraps row-major data of a known-valid shape. Unlike NewArray, an empty shape
+// produces a zero-dimensional array.
+func wrapArray(data []float64, shape []int) *Array {
+	return &Array{data: data, shape: append([]int{}, shape...), strides: contiguousStrides(shape)}
+}
+
+// shapeSize validates a shape and returns the number of elements it describes.
+func shapeSize(shape []int) (int, error) {
+	size := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			return 0, argumentError("NewArray", "negative dimension %d in shape %v", dim, shape)
+		}
+		size *= dim
+	}
+	return size, nil
+}
+
+// contiguousStrides returns the row-major strides of an array with the given shape.
+func contiguousStrides(shape []int) []int {
+	strides := make([]int, len(shape))
+	stride := 1
+	for axis := len(shape) - 1; axis >= 0; axis-- {
+		strides[axis] = stride
+		stride *= shape[axis]
+	}
+	return strides
+}
+
+// sameShape reports whether two shapes are identical.
+func sameShape(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// clampIndex resolves a possibly negative slice bound against an axis of length dim.
+func clampIndex(i, dim int) int {
+	if i < 0 {
+		i += dim
+	}
+	return min(max(i, 0), dim)
+}
@@ -0,0 +1,235 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewArray(t *testing.T) {
+	// Test case 1: Regular 2x3 array
+	arr, err := NewArray([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(arr.Shape(), []int{2, 3}) || !compareInts(arr.Strides(), []int{3, 1}) {
+		t.Errorf("Expected shape [2 3] and strides [3 1], got %v and %v", arr.Shape(), arr.Strides())
+	}
+	value, err := arr.At(1, 2)
+	if err != nil || value != 6 {
+		t.Errorf("Expected 6 at (1, 2), got %v (err %v)", value, err)
+	}
+
+	// Test case 2: Default one-dimensional shape
+	arr, err = NewArray([]float64{1, 2, 3})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if arr.Ndim() != 1 || arr.Size() != 3 {
+		t.Errorf("Expected a 1-D array of size 3, got shape %v", arr.Shape())
+	}
+
+	// Test case 3: Shape does not match the data
+	_, err = NewArray([]float64{1, 2, 3}, 2, 2)
+	if err == nil {
+		t.Error("Expected an error for mismatched shape, got none")
+	}
+
+	// Test case 4: Index out of bounds
+	_, err = arr.At(3)
+	if err == nil {
+		t.Error("Expected an error for out of bounds index, got none")
+	}
+}
+
+func TestArrayReshape(t *testing.T) {
+	// Test case 1: Reshape is a view of the original data
+	data := []float64{1, 2, 3, 4, 5, 6}
+	arr, _ := NewArray(data, 2, 3)
+	reshaped, err := arr.Reshape(3, -1)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(reshaped.Shape(), []int{3, 2}) {
+		t.Errorf("Expected shape [3 2], got %v", reshaped.Shape())
+	}
+	_ = reshaped.Set(60, 2, 1)
+	if data[5] != 60 {
+		t.Errorf("Expected reshape to share data, got %v", data)
+	}
+
+	// Test case 2: Reshape of a non-contiguous view copies in row-major order
+	transposed, _ := arr.Transpose()
+	flat, err := transposed.Reshape(-1)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(flat.Data(), []float64{1, 4, 2, 5, 3, 60}, 0.0001) {
+		t.Errorf("Expected [1 4 2 5 3 60], got %v", flat.Data())
+	}
+
+	// Test case 3: Incompatible shape
+	_, err = arr.Reshape(4, -1)
+	if err == nil {
+		t.Error("Expected an error for incompatible shape, got none")
+	}
+}
+
+func TestArrayTranspose(t *testing.T) {
+	// Test case 1: Default transpose reverses the axes
+	arr, _ := NewArray([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	transposed, err := arr.Transpose()
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	matrix, _ := transposed.ToMatrix()
+	expected := [][]float64{{1, 4}, {2, 5}, {3, 6}}
+	for i := range expected {
+		if !compareSlices(matrix[i], expected[i], 0.0001) {
+			t.Errorf("Expected %v, got %v", expected, matrix)
+		}
+	}
+	if transposed.IsContiguous() {
+		t.Error("Expected transposed view to be non-contiguous")
+	}
+
+	// Test case 2: Explicit permutation of a 3-D array
+	arr, _ = NewArray(make([]float64, 24), 2, 3, 4)
+	transposed, err = arr.Transpose(1, 2, 0)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(transposed.Shape(), []int{3, 4, 2}) {
+		t.Errorf("Expected shape [3 4 2], got %v", transposed.Shape())
+	}
+
+	// Test case 3: Invalid permutation
+	_, err = arr.Transpose(0, 0, 1)
+	if err == nil {
+		t.Error("Expected an error for invalid permutation, got none")
+	}
+}
+
+func TestArraySlice(t *testing.T) {
+	// Test case 1: Slice rows and every other column
+	arr, _ := NewArray([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 3, 4)
+	view, err := arr.Slice(Range{Start: 1, Stop: 3}, Range{Start: 0, Stop: 4, Step: 2})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(view.Data(), []float64{5, 7, 9, 11}, 0.0001) {
+		t.Errorf("Expected [5 7 9 11], got %v", view.Data())
+	}
+
+	// Test case 2: Writes through the view reach the original array
+	_ = view.Set(-1, 0, 0)
+	value, _ := arr.At(1, 0)
+	if value != -1 {
+		t.Errorf("Expected -1, got %v", value)
+	}
+
+	// Test case 3: Negative bounds and WholeAxis
+	view, err = arr.Slice(WholeAxis, Range{Start: -1, Stop: 4})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(view.Data(), []float64{4, 8, 12}, 0.0001) {
+		t.Errorf("Expected [4 8 12], got %v", view.Data())
+	}
+
+	// Test case 4: Too many ranges
+	_, err = arr.Slice(WholeAxis, WholeAxis, WholeAxis)
+	if err == nil {
+		t.Error("Expected an error for too many ranges, got none")
+	}
+}
+
+func TestArrayDense(t *testing.T) {
+	// Test case 1: Arrays created from a Dense matrix share its memory
+	dense := mat.NewDense(2, 2, []float64{1, 2, 3, 4})
+	arr := NewArrayFromDense(dense)
+	_ = arr.Set(10, 0, 1)
+	if dense.At(0, 1) != 10 {
+		t.Errorf("Expected shared memory, got %v", dense.At(0, 1))
+	}
+
+	// Test case 2: Round trip through ToDense
+	back, err := arr.ToDense()
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !mat.Equal(back, dense) {
+		t.Errorf("Expected %v, got %v", mat.Formatted(dense), mat.Formatted(back))
+	}
+
+	// Test case 3: Only 2-D arrays convert
+	arr, _ = NewArray([]float64{1, 2, 3})
+	_, err = arr.ToDense()
+	if err == nil {
+		t.Error("Expected an error for a 1-D array, got none")
+	}
+}
+
+func TestArrayMethods(t *testing.T) {
+	// Test case 1: Element-wise addition keeps the shape
+	a, _ := NewArray([]float64{1, 2, 3, 4}, 2, 2)
+	b, _ := NewArray([]float64{10, 20, 30, 40}, 2, 2)
+	sum, err := a.Add(2, b)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(sum.Shape(), []int{2, 2}) || !compareSlices(sum.Data(), []float64{11, 22, 33, 44}, 0.0001) {
+		t.Errorf("Expected [[11 22] [33 44]], got %v", sum)
+	}
+
+	// Test case 2: Mean along the first axis
+	mean, err := a.Mean(2, 0, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(mean.Data(), []float64{2, 3}, 0.0001) {
+		t.Errorf("Expected [2 3], got %v", mean)
+	}
+
+	// Test case 3: Matrix operations on a transposed view
+	transposed, _ := a.Transpose()
+	det, err := transposed.Determinant()
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if det != -2 {
+		t.Errorf("Expected -2, got %v", det)
+	}
+
+	// Test case 4: Mismatched shapes
+	c, _ := NewArray([]float64{1, 2, 3, 4}, 4)
+	_, err = a.Add(2, c)
+	if err == nil {
+		t.Error("Expected an error for mismatched shapes, got none")
+	}
+	// Test case 5: Option forms round, write to a caller's buffer and honor the NaN policy
+	out := make([]float64, 4)
+	quotient, err := a.DivideWith([]*Array{b}, WithPrecision(1), WithRounding(RoundHalfEven), WithOut(out))
+	if err != nil || !compareSlices(quotient.Data(), []float64{0.1, 0.1, 0.1, 0.1}, 0) || out[3] != 0.1 {
+		t.Errorf("Expected [0.1 0.1 0.1 0.1] in the buffer, got %v (err %v)", quotient, err)
+	}
+	withNaN, _ := NewArray([]float64{1, math.NaN()}, 2)
+	if _, err := withNaN.AddWith([]*Array{withNaN}, WithNaNPolicy(NaNRaise)); !errors.Is(err, ErrDomain) {
+		t.Errorf("Expected ErrDomain, got %v", err)
+	}
+}
+
+// compareInts checks if two int slices are equal.
+func compareInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
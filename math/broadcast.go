@@ -0,0 +1,88 @@
+package litearray
+
+// BroadcastShapes returns the shape that results from broadcasting the given shapes together
+// following the NumPy rules: shapes are aligned on their trailing axes, and two lengths are
+// compatible when they are equal or one of them is 1. Missing leading axes count as length 1.
+func BroadcastShapes(shapes ...[]int) ([]int, error) {
+	// The result has as many axes as the longest shape
+	ndim := 0
+	for _, shape := range shapes {
+		ndim = max(ndim, len(shape))
+	}
+	result := make([]int, ndim)
+	for i := range result {
+		result[i] = 1
+	}
+
+	// Merge the shapes one at a time, starting from the trailing axis
+	for s, shape := range shapes {
+		for i := 1; i <= len(shape); i++ {
+			dim := shape[len(shape)-i]
+			axis := ndim - i
+			switch {
+			case dim == result[axis] || dim == 1:
+			case result[axis] == 1:
+				result[axis] = dim
+			default:
+				other := firstWithDim(shapes[:s], i, result[axis])
+				return nil, shapeError("BroadcastShapes", [][]int{other, shape}, "operands could not be broadcast together with shapes %v and %v: dimension %d has incompatible sizes %d and %d", other, shape, axis, result[axis], dim)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// NewScalar creates a zero-dimensional array holding a single value. It broadcasts against arrays of any shape.
+func NewScalar(value float64) *Array {
+	return &Array{data: []float64{value}, shape: []int{}, strides: []int{}}
+}
+
+// BroadcastTo returns a view of the array with the given shape. Axes that are repeated share
+// a single element of the backing buffer, so writing through the view changes every copy.
+func (a *Array) BroadcastTo(shape ...int) (*Array, error) {
+	target, err := BroadcastShapes(a.shape, shape)
+	if err != nil {
+		return nil, err
+	}
+	if !sameShape(target, shape) {
+		return nil, shapeError("Array.BroadcastTo", [][]int{a.shape, shape}, "cannot broadcast array of shape %v to shape %v", a.shape, shape)
+	}
+
+	// Repeated axes get a stride of zero so every index maps to the same element
+	strides := make([]int, len(shape))
+	lead := len(shape) - len(a.shape)
+	for axis := range a.shape {
+		if a.shape[axis] == shape[lead+axis] {
+			strides[lead+axis] = a.strides[axis]
+		}
+	}
+
+	return &Array{data: a.data, shape: append([]int(nil), shape...), strides: strides, offset: a.offset}, nil
+}
+
+// broadcastLength returns the length that results from broadcasting one-dimensional arrays
+// together: every array must either have that length or hold a single element.
+func broadcastLength[T element](op string, arrays ...[]T) (int, error) {
+	length := 1
+	for _, array := range arrays {
+		switch n := len(array); {
+		case n == length || n == 1:
+		case length == 1:
+			length = n
+		default:
+			return 0, shapeError(op, shapesOf(arrays...), "arrays of lengths %d and %d cannot be broadcast together", length, n)
+		}
+	}
+	return length, nil
+}
+
+// firstWithDim returns the first of shapes whose i-th trailing axis has length dim.
+func firstWithDim(shapes [][]int, i, dim int) []int {
+	for _, shape := range shapes {
+		if len(shape) >= i && shape[len(shape)-i] == dim {
+			return shape
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package litearray
+
+import "testing"
+
+func TestBroadcastShapes(t *testing.T) {
+	// Test case 1: Row with matrix
+	shape, err := BroadcastShapes([]int{3, 4}, []int{4})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(shape, []int{3, 4}) {
+		t.Errorf("Expected [3 4], got %v", shape)
+	}
+
+	// Test case 2: Column with row produces an outer shape
+	shape, err = BroadcastShapes([]int{3, 1}, []int{1, 4}, []int{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(shape, []int{3, 4}) {
+		t.Errorf("Expected [3 4], got %v", shape)
+	}
+
+	// Test case 3: Incompatible trailing dimensions
+	_, err = BroadcastShapes([]int{2, 3}, []int{2})
+	if err == nil || err.Error() != "operands could not be broadcast together with shapes [2 3] and [2]: dimension 1 has incompatible sizes 3 and 2" {
+		t.Errorf("Expected a broadcasting error naming dimension 1, got %v", err)
+	}
+}
+
+func TestArrayBroadcasting(t *testing.T) {
+	matrix, _ := NewArray([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+
+	// Test case 1: Scalar with matrix
+	result, err := matrix.Multiply(2, NewScalar(10))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(result.Shape(), []int{2, 3}) || !compareSlices(result.Data(), []float64{10, 20, 30, 40, 50, 60}, 0.0001) {
+		t.Errorf("Expected [[10 20 30] [40 50 60]], got %v", result)
+	}
+
+	// Test case 2: Row with matrix
+	row, _ := NewArray([]float64{1, 1, 1})
+	result, err = matrix.Subtract(2, row)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result.Data(), []float64{0, 1, 2, 3, 4, 5}, 0.0001) {
+		t.Errorf("Expected [[0 1 2] [3 4 5]], got %v", result)
+	}
+
+	// Test case 3: Column with matrix
+	column, _ := NewArray([]float64{1, 2}, 2, 1)
+	result, err = matrix.Divide(2, column)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result.Data(), []float64{1, 2, 3, 2, 2.5, 3}, 0.0001) {
+		t.Errorf("Expected [[1 2 3] [2 2.5 3]], got %v", result)
+	}
+
+	// Test case 4: BroadcastTo shares the backing buffer
+	view, err := column.BroadcastTo(2, 3)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(view.Strides(), []int{1, 0}) || !compareSlices(view.Data(), []float64{1, 1, 1, 2, 2, 2}, 0.0001) {
+		t.Errorf("Expected [[1 1 1] [2 2 2]] with strides [1 0], got %v with strides %v", view, view.Strides())
+	}
+
+	// Test case 5: Incompatible shapes
+	bad, _ := NewArray([]float64{1, 2})
+	_, err = matrix.Add(2, bad)
+	if err == nil {
+		t.Error("Expected an error for incompatible shapes, got none")
+	}
+}
@@ -0,0 +1,298 @@
+package litearray
+
+import "math"
+
+// Cholesky factorizes a symmetric positive definite matrix as A = L·Lᵀ, where L is lower
+// triangular with a positive diagonal. It is about twice as fast as LU and is the natural
+// factorization for covariance and Gram matrices. The matrix must be symmetric to within the
+// tolerance, which defaults to √ε·max|aᵢⱼ| and can be set with WithTolerance; a matrix that is not
+// positive definite is reported as ErrNotPositiveDefinite. With WithOut the rows of L share the
+// given buffer.
+func Cholesky(matrix [][]float64, opts ...Option) ([][]float64, error) {
+	const op = "Cholesky"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkSymmetric(op, matrix); err != nil {
+		return nil, err
+	}
+
+	n := len(matrix)
+	data, err := c.result(op, n*n)
+	if err != nil {
+		return nil, err
+	}
+	l := rowsOf(data, n, n)
+	if err := factorCholesky(op, matrix, l); err != nil {
+		return nil, err
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), data)
+
+	return l, nil
+}
+
+// LDL factorizes a symmetric matrix as A = L·D·Lᵀ, where L is unit lower triangular and D is
+// diagonal, returned as the slice d. Unlike Cholesky it needs no square roots and accepts
+// indefinite matrices, as long as no leading principal minor is zero, which is reported as
+// ErrSingular. No pivoting is done.
+func LDL(matrix [][]float64, opts ...Option) (l [][]float64, d []float64, err error) {
+	const op = "LDL"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.checkSymmetric(op, matrix); err != nil {
+		return nil, nil, err
+	}
+
+	n := len(matrix)
+	data := make([]float64, n*n)
+	l = rowsOf(data, n, n)
+	d = make([]float64, n)
+	for j := 0; j < n; j++ {
+		l[j][j] = 1
+
+		// d_j = a_jj − Σ l_jk² d_k
+		sum := matrix[j][j]
+		for k := 0; k < j; k++ {
+			sum -= l[j][k] * l[j][k] * d[k]
+		}
+		if sum == 0 {
+			return nil, nil, valueError(op, ErrSingular, j, sum, "matrix has a zero leading principal minor and cannot be factorized without pivoting")
+		}
+		d[j] = sum
+
+		// l_ij = (a_ij − Σ l_ik l_jk d_k) / d_j
+		for i := j + 1; i < n; i++ {
+			sum := matrix[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k] * d[k]
+			}
+			l[i][j] = sum / d[j]
+		}
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), data)
+	c.round(op, matrixShapes(matrix), d)
+
+	return l, d, nil
+}
+
+// IsPositiveDefinite reports whether matrix is square, symmetric to within √ε·max|aᵢⱼ| and
+// positive definite, by attempting a Cholesky factorization.
+func IsPositiveDefinite(matrix [][]float64) bool {
+	const op = "IsPositiveDefinite"
+	c, err := newConfig(op, nil)
+	if err != nil {
+		return false
+	}
+	if err := c.checkSymmetric(op, matrix); err != nil {
+		return false
+	}
+	return factorCholesky(op, matrix, newMatrix(len(matrix), len(matrix))) == nil
+}
+
+// CholeskySolve solves A·x = b for a symmetric positive definite A using its Cholesky
+// factorization, with forward substitution on L followed by back substitution on Lᵀ.
+func CholeskySolve(a [][]float64, b []float64, opts ...Option) ([]float64, error) {
+	const op = "CholeskySolve"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkSymmetric(op, a); err != nil {
+		return nil, err
+	}
+	n := len(a)
+	if len(b) != n {
+		return nil, shapeError(op, [][]int{{n, n}, {len(b)}}, "right-hand side has length %d, expected %d", len(b), n)
+	}
+	if err := c.checkNaN(op, b); err != nil {
+		return nil, err
+	}
+
+	l := newMatrix(n, n)
+	if err := factorCholesky(op, a, l); err != nil {
+		return nil, err
+	}
+	x, err := c.result(op, n)
+	if err != nil {
+		return nil, err
+	}
+	choleskySolve(l, x, b)
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{n, n}, {n}}, x)
+
+	return x, nil
+}
+
+// CholeskyInverse calculates the inverse of a symmetric positive definite matrix from its
+// Cholesky factorization. The result is exactly symmetric.
+func CholeskyInverse(matrix [][]float64, opts ...Option) ([][]float64, error) {
+	const op = "CholeskyInverse"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkSymmetric(op, matrix); err != nil {
+		return nil, err
+	}
+
+	n := len(matrix)
+	l := newMatrix(n, n)
+	if err := factorCholesky(op, matrix, l); err != nil {
+		return nil, err
+	}
+	data, err := c.result(op, n*n)
+	if err != nil {
+		return nil, err
+	}
+	inverse := rowsOf(data, n, n)
+
+	// Column j of the inverse solves A·x = e_j; mirror the lower triangle to keep it symmetric
+	column := make([]float64, n)
+	for j := 0; j < n; j++ {
+		clear(column)
+		column[j] = 1
+		choleskySolve(l, column, column)
+		for i := j; i < n; i++ {
+			inverse[i][j] = column[i]
+			inverse[j][i] = column[i]
+		}
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), data)
+
+	return inverse, nil
+}
+
+// CholeskyUpdate returns the Cholesky factor of L·Lᵀ + x·xᵀ, given the lower triangular factor
+// L of a matrix, in O(n²) time instead of refactorizing. The upper triangle of l is ignored and
+// neither l nor x is modified.
+func CholeskyUpdate(l [][]float64, x []float64, opts ...Option) ([][]float64, error) {
+	return rankOneUpdate("CholeskyUpdate", l, x, 1, opts)
+}
+
+// CholeskyDowndate returns the Cholesky factor of L·Lᵀ − x·xᵀ, given the lower triangular factor
+// L of a matrix. A result that is no longer positive definite is reported as
+// ErrNotPositiveDefinite.
+func CholeskyDowndate(l [][]float64, x []float64, opts ...Option) ([][]float64, error) {
+	return rankOneUpdate("CholeskyDowndate", l, x, -1, opts)
+}
+
+func rankOneUpdate(op string, factor [][]float64, x []float64, sign float64, opts []Option) ([][]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSquare(op, factor); err != nil {
+		return nil, err
+	}
+	n := len(factor)
+	if len(x) != n {
+		return nil, shapeError(op, [][]int{{n, n}, {len(x)}}, "vector has length %d, expected %d", len(x), n)
+	}
+	for i := range factor {
+		if factor[i][i] <= 0 {
+			return nil, valueError(op, ErrNotPositiveDefinite, i, factor[i][i], "factor must have a positive diagonal, got %g at row %d", factor[i][i], i)
+		}
+	}
+	if err := c.checkNaN(op, factor...); err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, x); err != nil {
+		return nil, err
+	}
+
+	// Copy the lower triangle and the vector so the inputs are left alone
+	data, err := c.result(op, n*n)
+	if err != nil {
+		return nil, err
+	}
+	clear(data)
+	l := rowsOf(data, n, n)
+	for i := range factor {
+		copy(l[i][:i+1], factor[i][:i+1])
+	}
+	w := append([]float64(nil), x...)
+
+	// Apply a sequence of Givens-like rotations, one column at a time
+	for k := 0; k < n; k++ {
+		r2 := l[k][k]*l[k][k] + sign*w[k]*w[k]
+		if r2 <= 0 {
+			return nil, valueError(op, ErrNotPositiveDefinite, k, r2, "downdated matrix is not positive definite")
+		}
+		r := math.Sqrt(r2)
+		cos, sin := r/l[k][k], w[k]/l[k][k]
+		l[k][k] = r
+		for i := k + 1; i < n; i++ {
+			l[i][k] = (l[i][k] + sign*sin*w[i]) / cos
+			w[i] = cos*w[i] - sin*l[i][k]
+		}
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{n, n}, {n}}, data)
+
+	return l, nil
+}
+
+// factorCholesky writes the Cholesky factor of matrix, read from its lower triangle, into the
+// zeroed n×n matrix l.
+func factorCholesky(op string, matrix [][]float64, l [][]float64) error {
+	for _, row := range l {
+		clear(row)
+	}
+	for j := range matrix {
+		// l_jj = √(a_jj − Σ l_jk²)
+		sum := matrix[j][j]
+		for k := 0; k < j; k++ {
+			sum -= l[j][k] * l[j][k]
+		}
+		if !(sum > 0) {
+			return valueError(op, ErrNotPositiveDefinite, j, sum, "matrix is not positive definite: pivot %d is %g", j, sum)
+		}
+		l[j][j] = math.Sqrt(sum)
+
+		// l_ij = (a_ij − Σ l_ik l_jk) / l_jj
+		for i := j + 1; i < len(matrix); i++ {
+			sum := matrix[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			l[i][j] = sum / l[j][j]
+		}
+	}
+	return nil
+}
+
+// choleskySolve writes the solution of L·Lᵀ·x = b to x. x and b may be the same slice.
+func choleskySolve(l [][]float64, x, b []float64) {
+	n := len(l)
+	y := append([]float64(nil), b...)
+
+	// Forward substitution with L
+	for i := 0; i < n; i++ {
+		sum := y[i]
+		for k := 0; k < i; k++ {
+			sum -= l[i][k] * y[k]
+		}
+		y[i] = sum / l[i][i]
+	}
+
+	// Back substitution with Lᵀ
+	for i := n - 1; i >= 0; i-- {
+		sum := y[i]
+		for k := i + 1; k < n; k++ {
+			sum -= l[k][i] * y[k]
+		}
+		y[i] = sum / l[i][i]
+	}
+	copy(x, y)
+}
@@ -0,0 +1,134 @@
+package litearray
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	gram := [][]float64{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}}
+
+	// Test case 1: The classic 3x3 example
+	l, err := Cholesky(gram)
+	if err != nil || !compareMatrices(l, [][]float64{{2, 0, 0}, {6, 1, 0}, {-8, 5, 3}}, 1e-12) {
+		t.Errorf("Expected [[2 0 0] [6 1 0] [-8 5 3]], got %v (err %v)", l, err)
+	}
+	if !compareMatrices(multiplyMatrices(l, transposeMatrix(l)), gram, 1e-12) {
+		t.Errorf("Expected L·Lᵀ = A, got %v", multiplyMatrices(l, transposeMatrix(l)))
+	}
+
+	// Test case 2: Output buffer
+	out := make([]float64, 9)
+	l, err = Cholesky(gram, WithOut(out))
+	if err != nil || out[3] != 6 || &l[1][0] != &out[3] {
+		t.Errorf("Expected L in the output buffer, got %v (err %v)", out, err)
+	}
+
+	// Test case 3: Indefinite and non-symmetric matrices
+	_, err = Cholesky([][]float64{{1, 2}, {2, 1}})
+	var valueErr *ValueError
+	if !errors.Is(err, ErrNotPositiveDefinite) || !errors.As(err, &valueErr) || valueErr.Index != 1 {
+		t.Errorf("Expected ErrNotPositiveDefinite at index 1, got %v", err)
+	}
+	_, err = Cholesky([][]float64{{2, 1}, {0, 2}})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestLDL(t *testing.T) {
+	// Test case 1: A positive definite matrix
+	l, d, err := LDL([][]float64{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}})
+	if err != nil || !compareMatrices(l, [][]float64{{1, 0, 0}, {3, 1, 0}, {-4, 5, 1}}, 1e-12) || !compareSlices(d, []float64{4, 1, 9}, 1e-12) {
+		t.Errorf("Expected L [[1 0 0] [3 1 0] [-4 5 1]] and D [4 1 9], got %v and %v (err %v)", l, d, err)
+	}
+
+	// Test case 2: Indefinite matrices factorize too
+	l, d, err = LDL([][]float64{{1, 2}, {2, 1}})
+	if err != nil || !compareMatrices(l, [][]float64{{1, 0}, {2, 1}}, 0) || !compareSlices(d, []float64{1, -3}, 0) {
+		t.Errorf("Expected L [[1 0] [2 1]] and D [1 -3], got %v and %v (err %v)", l, d, err)
+	}
+
+	// Test case 3: A zero leading minor
+	_, _, err = LDL([][]float64{{0, 1}, {1, 0}})
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+}
+
+func TestIsPositiveDefinite(t *testing.T) {
+	// Test case 1: Positive definite, indefinite, semidefinite and non-square matrices
+	cases := []struct {
+		matrix   [][]float64
+		expected bool
+	}{
+		{[][]float64{{2, 1}, {1, 2}}, true},
+		{[][]float64{{1, 2}, {2, 1}}, false},
+		{[][]float64{{1, 1}, {1, 1}}, false},
+		{[][]float64{{1, 2}}, false},
+	}
+	for _, tc := range cases {
+		if got := IsPositiveDefinite(tc.matrix); got != tc.expected {
+			t.Errorf("Expected %v for %v, got %v", tc.expected, tc.matrix, got)
+		}
+	}
+}
+
+func TestCholeskySolveAndInverse(t *testing.T) {
+	a := [][]float64{{4, 2}, {2, 3}}
+
+	// Test case 1: Solve agrees with the LU solver
+	x, err := CholeskySolve(a, []float64{2, 1})
+	if err != nil || !compareSlices(x, []float64{0.5, 0}, 1e-12) {
+		t.Errorf("Expected [0.5 0], got %v (err %v)", x, err)
+	}
+
+	// Test case 2: The inverse is symmetric and inverts A
+	inverse, err := CholeskyInverse(a)
+	if err != nil || !compareMatrices(inverse, [][]float64{{0.375, -0.25}, {-0.25, 0.5}}, 1e-12) || inverse[0][1] != inverse[1][0] {
+		t.Errorf("Expected [[0.375 -0.25] [-0.25 0.5]], got %v (err %v)", inverse, err)
+	}
+
+	// Test case 3: Length mismatch
+	_, err = CholeskySolve(a, []float64{1, 2, 3})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
+
+func TestCholeskyUpdateAndDowndate(t *testing.T) {
+	a := [][]float64{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}}
+	x := []float64{1, 2, 3}
+	l, _ := Cholesky(a)
+
+	// Test case 1: Update matches factorizing A + x·xᵀ
+	updated, err := CholeskyUpdate(l, x)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sum := newMatrix(3, 3)
+	for i := range a {
+		for j := range a {
+			sum[i][j] = a[i][j] + x[i]*x[j]
+		}
+	}
+	expected, _ := Cholesky(sum)
+	if !compareMatrices(updated, expected, 1e-12) {
+		t.Errorf("Expected %v, got %v", expected, updated)
+	}
+	if !compareSlices(x, []float64{1, 2, 3}, 0) || l[1][0] != 6 {
+		t.Errorf("Expected inputs to be left alone, got %v and %v", l, x)
+	}
+
+	// Test case 2: Downdate undoes the update
+	downdated, err := CholeskyDowndate(updated, x)
+	if err != nil || !compareMatrices(downdated, l, 1e-12) {
+		t.Errorf("Expected %v, got %v (err %v)", l, downdated, err)
+	}
+
+	// Test case 3: Downdating past positive definiteness
+	_, err = CholeskyDowndate([][]float64{{1, 0}, {0, 1}}, []float64{0, 2})
+	if !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("Expected ErrNotPositiveDefinite, got %v", err)
+	}
+}
@@ -0,0 +1,456 @@
+package litearray
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// The complex functions mirror their float64 counterparts for complex128 input, such as the
+// eigenvalues returned by Eigenvalues3x3AndHigher and Eigen. Rounding applies to the real and
+// imaginary parts separately. Functions returning complex128 do not accept WithOut, whose slice
+// is []float64.
+
+// AddComplex adds complex arrays element-wise, broadcasting arrays that hold a single element
+// against the others.
+func AddComplex(arrays [][]complex128, opts ...Option) ([]complex128, error) {
+	return foldComplex("AddComplex", arrays, opts, false, false, "at least two arrays are required to perform addition",
+		func(acc, v complex128) complex128 { return acc + v })
+}
+
+// SubtractComplex subtracts the remaining complex arrays from the first element-wise,
+// broadcasting arrays that hold a single element against the others.
+func SubtractComplex(arrays [][]complex128, opts ...Option) ([]complex128, error) {
+	return foldComplex("SubtractComplex", arrays, opts, true, false, "at least two arrays are required to perform subtraction",
+		func(acc, v complex128) complex128 { return acc - v })
+}
+
+// MultiplyComplex multiplies complex arrays element-wise, broadcasting arrays that hold a single
+// element against the others.
+func MultiplyComplex(arrays [][]complex128, opts ...Option) ([]complex128, error) {
+	return foldComplex("MultiplyComplex", arrays, opts, true, false, "at least two arrays are required to perform multiplication",
+		func(acc, v complex128) complex128 { return acc * v })
+}
+
+// DivideComplex divides the first complex array by the remaining arrays element-wise,
+// broadcasting arrays that hold a single element against the others. A zero divisor is reported
+// as ErrDivideByZero.
+func DivideComplex(arrays [][]complex128, opts ...Option) ([]complex128, error) {
+	return foldComplex("DivideComplex", arrays, opts, true, true, "at least two arrays are required to perform division",
+		func(acc, v complex128) complex128 { return acc / v })
+}
+
+// AbsComplex returns the modulus |z| of each element.
+func AbsComplex(values []complex128, opts ...Option) ([]float64, error) {
+	return mapComplex("AbsComplex", values, opts, cmplx.Abs)
+}
+
+// AngleComplex returns the argument of each element in radians, in the range [−π, π].
+func AngleComplex(values []complex128, opts ...Option) ([]float64, error) {
+	return mapComplex("AngleComplex", values, opts, cmplx.Phase)
+}
+
+// ConjComplex returns the complex conjugate of each element.
+func ConjComplex(values []complex128, opts ...Option) ([]complex128, error) {
+	const op = "ConjComplex"
+	c, err := complexConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkComplexNaN(op, values); err != nil {
+		return nil, err
+	}
+
+	result := make([]complex128, len(values))
+	for i, z := range values {
+		result[i] = cmplx.Conj(z)
+	}
+
+	// Apply rounding if precision is non-negative
+	c.roundComplex(op, shapesOf(values), result)
+
+	return result, nil
+}
+
+// ToComplex converts real values to complex128 with zero imaginary parts.
+func ToComplex(values []float64) []complex128 {
+	result := make([]complex128, len(values))
+	for i, v := range values {
+		result[i] = complex(v, 0)
+	}
+	return result
+}
+
+// RealPart returns the real part of each element.
+func RealPart(values []complex128) []float64 {
+	result := make([]float64, len(values))
+	for i, z := range values {
+		result[i] = real(z)
+	}
+	return result
+}
+
+// ImagPart returns the imaginary part of each element.
+func ImagPart(values []complex128) []float64 {
+	result := make([]float64, len(values))
+	for i, z := range values {
+		result[i] = imag(z)
+	}
+	return result
+}
+
+// ToComplexMatrix converts a real matrix to complex128 with zero imaginary parts, so it can be
+// combined with complex matrices such as eigenvectors.
+func ToComplexMatrix(matrix [][]float64) ([][]complex128, error) {
+	cols, err := checkRectangular("ToComplexMatrix", matrix)
+	if err != nil {
+		return nil, err
+	}
+	result := newComplexMatrix(len(matrix), cols)
+	for i, row := range matrix {
+		for j, v := range row {
+			result[i][j] = complex(v, 0)
+		}
+	}
+	return result, nil
+}
+
+// MatMulComplex calculates the matrix product A·B of an m×k and a k×n complex matrix.
+func MatMulComplex(a, b [][]complex128, opts ...Option) ([][]complex128, error) {
+	const op = "MatMulComplex"
+	c, err := complexConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	k, err := checkRectangular(op, a)
+	if err != nil {
+		return nil, err
+	}
+	n, err := checkRectangular(op, b)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != k {
+		return nil, shapeError(op, [][]int{{len(a), k}, {len(b), n}}, "matrices of shapes %dx%d and %dx%d cannot be multiplied", len(a), k, len(b), n)
+	}
+	if err := c.checkComplexNaN(op, flattenComplex(a)); err != nil {
+		return nil, err
+	}
+	if err := c.checkComplexNaN(op, flattenComplex(b)); err != nil {
+		return nil, err
+	}
+
+	// Accumulate row i of the product as a combination of the rows of B
+	m := len(a)
+	result := newComplexMatrix(m, n)
+	c.parallelChunks(m, max(1, minChunk/max(k*n, 1)), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			out := result[i]
+			for p, v := range a[i] {
+				if v == 0 {
+					continue
+				}
+				for j, w := range b[p] {
+					out[j] += v * w
+				}
+			}
+		}
+	})
+
+	// Apply rounding if precision is non-negative
+	c.roundComplexMatrix(op, [][]int{{m, k}, {k, n}}, result)
+
+	return result, nil
+}
+
+// HermitianTranspose returns the conjugate transpose Aᴴ of a complex matrix.
+func HermitianTranspose(matrix [][]complex128, opts ...Option) ([][]complex128, error) {
+	const op = "HermitianTranspose"
+	c, err := complexConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	cols, err := checkRectangular(op, matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := len(matrix)
+	result := newComplexMatrix(cols, rows)
+	for i, row := range matrix {
+		for j, z := range row {
+			result[j][i] = cmplx.Conj(z)
+		}
+	}
+
+	// Apply rounding if precision is non-negative
+	c.roundComplexMatrix(op, [][]int{{rows, cols}}, result)
+
+	return result, nil
+}
+
+// InverseComplex calculates the inverse of a square complex matrix from its LU factorization with
+// partial pivoting. A singular matrix is reported as ErrSingular.
+func InverseComplex(matrix [][]complex128, opts ...Option) ([][]complex128, error) {
+	const op = "InverseComplex"
+	c, err := complexConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	f, err := factorComplexLU(op, matrix)
+	if err != nil {
+		return nil, err
+	}
+	if f.singular >= 0 {
+		return nil, valueError(op, ErrSingular, f.singular, 0, "matrix is singular and cannot be inverted")
+	}
+	inverse := f.inverse()
+
+	// Apply rounding if precision is non-negative
+	n := len(matrix)
+	c.roundComplexMatrix(op, [][]int{{n, n}}, inverse)
+
+	return inverse, nil
+}
+
+// DeterminantComplex calculates the determinant of a square complex matrix from its LU
+// factorization with partial pivoting.
+func DeterminantComplex(matrix [][]complex128) (complex128, error) {
+	f, err := factorComplexLU("DeterminantComplex", matrix)
+	if err != nil {
+		return 0, err
+	}
+	return f.determinant(), nil
+}
+
+// complexLU holds an LU factorization of a complex matrix in the compact form of luFactors.
+type complexLU struct {
+	lu       [][]complex128
+	perm     []int   // perm[i] is the row of the original matrix moved to row i
+	sign     float64 // determinant of the permutation, 1 or -1
+	singular int     // first column without a non-zero pivot, or -1
+}
+
+// factorComplexLU computes the LU factorization of a square complex matrix with partial pivoting,
+// checking that every row has as many entries as the matrix has rows.
+func factorComplexLU(op string, matrix [][]complex128) (*complexLU, error) {
+	if err := checkSquare(op, matrix); err != nil {
+		return nil, err
+	}
+	return decomposeComplex(matrix), nil
+}
+
+// decomposeComplex factorizes a square complex matrix, choosing the pivot of largest modulus.
+func decomposeComplex(matrix [][]complex128) *complexLU {
+	n := len(matrix)
+	lu := newComplexMatrix(n, n)
+	for i := range matrix {
+		copy(lu[i], matrix[i])
+	}
+	f := &complexLU{lu: lu, perm: make([]int, n), sign: 1, singular: -1}
+	for i := range f.perm {
+		f.perm[i] = i
+	}
+
+	for k := 0; k < n; k++ {
+		// Bring the entry of largest modulus onto the diagonal
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if cmplx.Abs(lu[i][k]) > cmplx.Abs(lu[pivot][k]) {
+				pivot = i
+			}
+		}
+		if pivot != k {
+			lu[pivot], lu[k] = lu[k], lu[pivot]
+			f.perm[pivot], f.perm[k] = f.perm[k], f.perm[pivot]
+			f.sign = -f.sign
+		}
+
+		// A zero pivot leaves nothing to eliminate in this column
+		if lu[k][k] == 0 {
+			if f.singular < 0 {
+				f.singular = k
+			}
+			continue
+		}
+
+		// Eliminate the entries below the pivot, storing the multipliers in their place
+		for i := k + 1; i < n; i++ {
+			lu[i][k] /= lu[k][k]
+			factor := lu[i][k]
+			if factor == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				lu[i][j] -= factor * lu[k][j]
+			}
+		}
+	}
+	return f
+}
+
+// determinant returns the product of the diagonal of U with the sign of the permutation.
+func (f *complexLU) determinant() complex128 {
+	det := complex(f.sign, 0)
+	for i := range f.lu {
+		det *= f.lu[i][i]
+	}
+	return det
+}
+
+// inverse returns the inverse of the factorized matrix, solving for one column of the identity at
+// a time. It assumes the factorization is not singular.
+func (f *complexLU) inverse() [][]complex128 {
+	n := len(f.lu)
+	inverse := newComplexMatrix(n, n)
+	column := make([]complex128, n)
+	for j := 0; j < n; j++ {
+		// Permute e_j, then substitute forward with L and back with U
+		for i, row := range f.perm {
+			column[i] = 0
+			if row == j {
+				column[i] = 1
+			}
+		}
+		for i := 0; i < n; i++ {
+			for p := 0; p < i; p++ {
+				column[i] -= f.lu[i][p] * column[p]
+			}
+		}
+		for i := n - 1; i >= 0; i-- {
+			for p := i + 1; p < n; p++ {
+				column[i] -= f.lu[i][p] * column[p]
+			}
+			column[i] /= f.lu[i][i]
+		}
+		for i := 0; i < n; i++ {
+			inverse[i][j] = column[i]
+		}
+	}
+	return inverse
+}
+
+// foldComplex combines complex arrays element-wise with step, repeating single-element arrays.
+// requireFirst rejects a nil or empty first array as in checkOperands, and divide rejects zero
+// divisors.
+func foldComplex(op string, arrays [][]complex128, opts []Option, requireFirst, divide bool, tooFew string, step func(acc, v complex128) complex128) ([]complex128, error) {
+	c, err := complexConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that the arrays can be broadcast to a common length
+	length, err := checkOperands(op, arrays, requireFirst, tooFew)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkComplexNaN(op, arrays...); err != nil {
+		return nil, err
+	}
+
+	// Reject zero divisors before doing any work, as Divide does
+	if divide {
+		for _, array := range arrays[1:] {
+			for i := 0; i < length; i++ {
+				if array[i%len(array)] == 0 {
+					return nil, valueError(op, ErrDivideByZero, i, 0, "division by zero at index %d", i)
+				}
+			}
+		}
+	}
+
+	result := make([]complex128, length)
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			acc := arrays[0][i%len(arrays[0])]
+			for _, array := range arrays[1:] {
+				acc = step(acc, array[i%len(array)])
+			}
+			result[i] = acc
+		}
+	})
+
+	// Apply rounding if precision is non-negative
+	c.roundComplex(op, shapesOf(arrays...), result)
+
+	return result, nil
+}
+
+// mapComplex applies fn to each complex element, producing real results.
+func mapComplex(op string, values []complex128, opts []Option, fn func(complex128) float64) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkComplexNaN(op, values); err != nil {
+		return nil, err
+	}
+
+	result, err := c.result(op, len(values))
+	if err != nil {
+		return nil, err
+	}
+	c.parallel(len(values), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			result[i] = fn(values[i])
+		}
+	})
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(values), result)
+
+	return result, nil
+}
+
+// complexConfig builds the config of a function returning complex128, which cannot write to
+// WithOut.
+func complexConfig(op string, opts []Option) (*config, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.out != nil {
+		return nil, argumentError(op, "WithOut is only supported by functions returning float64")
+	}
+	return c, nil
+}
+
+// checkComplexNaN rejects elements with a NaN real or imaginary part under NaNRaise.
+func (c *config) checkComplexNaN(op string, arrays ...[]complex128) error {
+	if c.nanPolicy != NaNRaise {
+		return nil
+	}
+	for _, array := range arrays {
+		for i, z := range array {
+			if math.IsNaN(real(z)) || math.IsNaN(imag(z)) {
+				return valueError(op, ErrDomain, i, math.NaN(), "NaN value at index %d", i)
+			}
+		}
+	}
+	return nil
+}
+
+// roundComplexMatrix rounds the entries of a complex matrix in place.
+func (c *config) roundComplexMatrix(op string, shapes [][]int, matrix [][]complex128) {
+	if c.precision < 0 {
+		return
+	}
+	data := flattenComplex(matrix)
+	c.roundComplex(op, shapes, data)
+	for i, row := range matrix {
+		copy(row, data[i*len(row):])
+	}
+}
+
+// newComplexMatrix returns a zero rows×cols complex matrix whose rows share a single buffer.
+func newComplexMatrix(rows, cols int) [][]complex128 {
+	return rowsOf(make([]complex128, rows*cols), rows, cols)
+}
+
+// flattenComplex returns the entries of a rectangular complex matrix in row-major order.
+func flattenComplex(matrix [][]complex128) []complex128 {
+	var data []complex128
+	for _, row := range matrix {
+		data = append(data, row...)
+	}
+	return data
+}
@@ -0,0 +1,97 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestComplexArithmetic(t *testing.T) {
+	a := []complex128{1 + 2i, 3 - 1i}
+	b := []complex128{2i}
+
+	// Test case 1: Element-wise operations with broadcasting
+	sum, _ := AddComplex([][]complex128{a, b})
+	difference, _ := SubtractComplex([][]complex128{a, b})
+	product, _ := MultiplyComplex([][]complex128{a, b})
+	quotient, _ := DivideComplex([][]complex128{a, b})
+	if !compareComplexSlices(sum, []complex128{1 + 4i, 3 + 1i}, 0) || !compareComplexSlices(difference, []complex128{1, 3 - 3i}, 0) ||
+		!compareComplexSlices(product, []complex128{-4 + 2i, 2 + 6i}, 0) || !compareComplexSlices(quotient, []complex128{1 - 0.5i, -0.5 - 1.5i}, 1e-15) {
+		t.Errorf("Unexpected results %v, %v, %v and %v", sum, difference, product, quotient)
+	}
+
+	// Test case 2: Modulus, argument and conjugate, with rounding
+	abs, _ := AbsComplex([]complex128{3 + 4i, -2})
+	angle, _ := AngleComplex([]complex128{1i, -1}, WithPrecision(4))
+	conj, _ := ConjComplex([]complex128{1.234 + 5.678i}, WithPrecision(1))
+	if !compareSlices(abs, []float64{5, 2}, 0) || !compareSlices(angle, []float64{1.5708, 3.1416}, 0) || !compareComplexSlices(conj, []complex128{1.2 - 5.7i}, 0) {
+		t.Errorf("Unexpected results %v, %v and %v", abs, angle, conj)
+	}
+
+	// Test case 3: Invalid input
+	if _, err := DivideComplex([][]complex128{a, {0}}); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v", err)
+	}
+	if _, err := AddComplex([][]complex128{a, {1, 2, 3}}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := AddComplex([][]complex128{a, b}, WithOut(make([]float64, 2))); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := ConjComplex([]complex128{complex(math.NaN(), 0)}, WithNaNPolicy(NaNRaise)); !errors.Is(err, ErrDomain) {
+		t.Errorf("Expected ErrDomain, got %v", err)
+	}
+}
+
+func TestComplexMatrices(t *testing.T) {
+	a := [][]complex128{{1, 1i}, {2 - 1i, 3}}
+
+	// Test case 1: Products and the Hermitian transpose
+	product, err := MatMulComplex(a, [][]complex128{{1i}, {1}})
+	if err != nil || !compareComplexSlices(product[0], []complex128{2i}, 0) || !compareComplexSlices(product[1], []complex128{4 + 2i}, 0) {
+		t.Errorf("Expected [[2i] [4+2i]], got %v (err %v)", product, err)
+	}
+	hermitian, _ := HermitianTranspose([][]complex128{{1 + 1i, 2}})
+	if len(hermitian) != 2 || hermitian[0][0] != 1-1i || hermitian[1][0] != 2 {
+		t.Errorf("Expected [[1-1i] [2]], got %v", hermitian)
+	}
+
+	// Test case 2: Inverse and determinant
+	det, err := DeterminantComplex(a)
+	if err != nil || cmplx.Abs(det-(2-2i)) > 1e-15 {
+		t.Errorf("Expected 2-2i, got %v (err %v)", det, err)
+	}
+	inverse, err := InverseComplex(a)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	identity, _ := MatMulComplex(a, inverse)
+	if !compareComplexSlices(identity[0], []complex128{1, 0}, 1e-15) || !compareComplexSlices(identity[1], []complex128{0, 1}, 1e-15) {
+		t.Errorf("Expected A·A⁻¹ = I, got %v", identity)
+	}
+
+	// Test case 3: Eigenvalues feed straight in; their product is the determinant
+	matrix := [][]float64{{0, -1, 0}, {1, 0, 0}, {0, 0, 2}}
+	values, _ := Eigenvalues3x3AndHigher(matrix)
+	moduli, _ := AbsComplex(values)
+	if !compareSlices(moduli, []float64{1, 1, 2}, 1e-12) && !compareSlices(moduli, []float64{2, 1, 1}, 1e-12) {
+		t.Errorf("Expected moduli 1, 1 and 2, got %v", moduli)
+	}
+	complexMatrix, _ := ToComplexMatrix(matrix)
+	det, _ = DeterminantComplex(complexMatrix)
+	if cmplx.Abs(det-values[0]*values[1]*values[2]) > 1e-12 || !compareSlices(RealPart([]complex128{det}), []float64{2}, 1e-12) {
+		t.Errorf("Expected a determinant of 2, got %v", det)
+	}
+
+	// Test case 4: Invalid input
+	if _, err := InverseComplex([][]complex128{{1, 1i}, {1i, -1}}); !errors.Is(err, ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	if _, err := MatMulComplex(a, [][]complex128{{1, 2}}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := DeterminantComplex([][]complex128{{1, 2}}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+package litearray
+
+// Identity returns the n×n identity matrix.
+func Identity(n int) ([][]float64, error) {
+	if err := checkDimensions("Identity", n, n); err != nil {
+		return nil, err
+	}
+	return identity(n), nil
+}
+
+// Zeros returns a rows×cols matrix of zeros.
+func Zeros(rows, cols int) ([][]float64, error) {
+	return full("Zeros", rows, cols, 0)
+}
+
+// Ones returns a rows×cols matrix of ones.
+func Ones(rows, cols int) ([][]float64, error) {
+	return full("Ones", rows, cols, 1)
+}
+
+// Full returns a rows×cols matrix with every entry set to value.
+func Full(rows, cols int, value float64) ([][]float64, error) {
+	return full("Full", rows, cols, value)
+}
+
+func full(op string, rows, cols int, value float64) ([][]float64, error) {
+	if err := checkDimensions(op, rows, cols); err != nil {
+		return nil, err
+	}
+	matrix := newMatrix(rows, cols)
+	if value != 0 {
+		for _, row := range matrix {
+			for j := range row {
+				row[j] = value
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// Eye returns a rows×cols matrix with ones on the k-th diagonal and zeros elsewhere. k = 0 is the
+// main diagonal, positive k lies above it and negative k below it.
+func Eye(rows, cols, k int) ([][]float64, error) {
+	if err := checkDimensions("Eye", rows, cols); err != nil {
+		return nil, err
+	}
+	matrix := newMatrix(rows, cols)
+	for i := max(0, -k); i < rows && i+k < cols; i++ {
+		matrix[i][i+k] = 1
+	}
+	return matrix, nil
+}
+
+// Diag returns the square matrix with values on its k-th diagonal and zeros elsewhere, with k as
+// in Eye. The matrix has len(values) + |k| rows.
+func Diag(values []float64, k int) ([][]float64, error) {
+	if len(values) == 0 {
+		return nil, emptyError("Diag", shapesOf(values), "diagonal values cannot be empty")
+	}
+	n := len(values) + max(k, -k)
+	matrix := newMatrix(n, n)
+	for i, v := range values {
+		matrix[i+max(0, -k)][i+max(0, k)] = v
+	}
+	return matrix, nil
+}
+
+// Toeplitz returns the matrix with constant diagonals whose first column is column and whose
+// first row is row. The first element of row is ignored in favour of column[0]. A nil row gives
+// the symmetric Toeplitz matrix with row equal to column.
+func Toeplitz(column, row []float64) ([][]float64, error) {
+	if row == nil {
+		row = column
+	}
+	if len(column) == 0 || len(row) == 0 {
+		return nil, emptyError("Toeplitz", shapesOf(column, row), "first column and row cannot be empty")
+	}
+	matrix := newMatrix(len(column), len(row))
+	for i := range matrix {
+		for j := range matrix[i] {
+			if i >= j {
+				matrix[i][j] = column[i-j]
+			} else {
+				matrix[i][j] = row[j-i]
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// Hankel returns the matrix with constant anti-diagonals whose first column is column and whose
+// last row is row. The first element of row is ignored in favour of the last element of column.
+// A nil row gives the square matrix with zeros below the main anti-diagonal.
+func Hankel(column, row []float64) ([][]float64, error) {
+	if len(column) == 0 {
+		return nil, emptyError("Hankel", shapesOf(column, row), "first column cannot be empty")
+	}
+	if row == nil {
+		row = make([]float64, len(column))
+		row[0] = column[len(column)-1]
+	}
+	if len(row) == 0 {
+		return nil, emptyError("Hankel", shapesOf(column, row), "last row cannot be empty")
+	}
+	m := len(column)
+	matrix := newMatrix(m, len(row))
+	for i := range matrix {
+		for j := range matrix[i] {
+			if i+j < m {
+				matrix[i][j] = column[i+j]
+			} else {
+				matrix[i][j] = row[i+j-m+1]
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// Vandermonde returns the matrix whose row i holds the powers 1, xᵢ, xᵢ², …, xᵢ^(cols−1), as used
+// to fit polynomials by least squares.
+func Vandermonde(x []float64, cols int) ([][]float64, error) {
+	if err := checkDimensions("Vandermonde", len(x), cols); err != nil {
+		return nil, err
+	}
+	matrix := newMatrix(len(x), cols)
+	for i, v := range x {
+		power := 1.0
+		for j := range matrix[i] {
+			matrix[i][j] = power
+			power *= v
+		}
+	}
+	return matrix, nil
+}
+
+// Hilbert returns the n×n Hilbert matrix with entries 1/(i + j + 1), a classic example of an
+// ill-conditioned matrix.
+func Hilbert(n int) ([][]float64, error) {
+	if err := checkDimensions("Hilbert", n, n); err != nil {
+		return nil, err
+	}
+	matrix := newMatrix(n, n)
+	for i := range matrix {
+		for j := range matrix[i] {
+			matrix[i][j] = 1 / float64(i+j+1)
+		}
+	}
+	return matrix, nil
+}
+
+// Circulant returns the square matrix whose first column is column and whose every other column
+// is the previous one rotated down by one.
+func Circulant(column []float64) ([][]float64, error) {
+	if len(column) == 0 {
+		return nil, emptyError("Circulant", shapesOf(column), "first column cannot be empty")
+	}
+	n := len(column)
+	matrix := newMatrix(n, n)
+	for i := range matrix {
+		for j := range matrix[i] {
+			matrix[i][j] = column[(i-j+n)%n]
+		}
+	}
+	return matrix, nil
+}
+
+// HStack joins matrices with the same number of rows side by side.
+func HStack(matrices ...[][]float64) ([][]float64, error) {
+	const op = "HStack"
+	widths, err := checkBlocks(op, matrices)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := len(matrices[0]), 0
+	for i, width := range widths {
+		if len(matrices[i]) != rows {
+			return nil, shapeError(op, blockShapes(matrices), "all matrices must have the same number of rows")
+		}
+		cols += width
+	}
+
+	result := newMatrix(rows, cols)
+	for i, row := range result {
+		offset := 0
+		for _, matrix := range matrices {
+			offset += copy(row[offset:], matrix[i])
+		}
+	}
+	return result, nil
+}
+
+// VStack joins matrices with the same number of columns one above the other.
+func VStack(matrices ...[][]float64) ([][]float64, error) {
+	const op = "VStack"
+	widths, err := checkBlocks(op, matrices)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := 0, widths[0]
+	for i, width := range widths {
+		if width != cols {
+			return nil, shapeError(op, blockShapes(matrices), "all matrices must have the same number of columns")
+		}
+		rows += len(matrices[i])
+	}
+
+	result := newMatrix(rows, cols)
+	i := 0
+	for _, matrix := range matrices {
+		for _, row := range matrix {
+			copy(result[i], row)
+			i++
+		}
+	}
+	return result, nil
+}
+
+// BlockDiag returns the block diagonal matrix with the given matrices along its diagonal and
+// zeros elsewhere. The matrices need not be square.
+func BlockDiag(matrices ...[][]float64) ([][]float64, error) {
+	widths, err := checkBlocks("BlockDiag", matrices)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := 0, 0
+	for i, width := range widths {
+		rows += len(matrices[i])
+		cols += width
+	}
+
+	result := newMatrix(rows, cols)
+	top, left := 0, 0
+	for b, matrix := range matrices {
+		for i, row := range matrix {
+			copy(result[top+i][left:], row)
+		}
+		top += len(matrix)
+		left += widths[b]
+	}
+	return result, nil
+}
+
+// Kron returns the Kronecker product of an m×n and a p×q matrix: the mp×nq block matrix whose
+// block (i, j) is a[i][j]·B.
+func Kron(a, b [][]float64) ([][]float64, error) {
+	const op = "Kron"
+	n, err := checkRectangular(op, a)
+	if err != nil {
+		return nil, err
+	}
+	q, err := checkRectangular(op, b)
+	if err != nil {
+		return nil, err
+	}
+
+	p := len(b)
+	result := newMatrix(len(a)*p, n*q)
+	for i, row := range a {
+		for j, v := range row {
+			for k, inner := range b {
+				out := result[i*p+k][j*q : (j+1)*q]
+				for l, w := range inner {
+					out[l] = v * w
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// Tril returns a copy of matrix with the entries above its k-th diagonal set to zero, with k as
+// in Eye. Tril(matrix, 0) is the lower triangle including the diagonal.
+func Tril(matrix [][]float64, k int) ([][]float64, error) {
+	return triangle("Tril", matrix, func(i, j int) bool { return j-i <= k })
+}
+
+// Triu returns a copy of matrix with the entries below its k-th diagonal set to zero, with k as
+// in Eye. Triu(matrix, 0) is the upper triangle including the diagonal.
+func Triu(matrix [][]float64, k int) ([][]float64, error) {
+	return triangle("Triu", matrix, func(i, j int) bool { return j-i >= k })
+}
+
+func triangle(op string, matrix [][]float64, keep func(i, j int) bool) ([][]float64, error) {
+	cols, err := checkRectangular(op, matrix)
+	if err != nil {
+		return nil, err
+	}
+	result := newMatrix(len(matrix), cols)
+	for i, row := range matrix {
+		for j, v := range row {
+			if keep(i, j) {
+				result[i][j] = v
+			}
+		}
+	}
+	return result, nil
+}
+
+// Diagonal returns the entries on the k-th diagonal of a matrix, with k as in Eye. A diagonal
+// that lies outside the matrix is empty.
+func Diagonal(matrix [][]float64, k int) ([]float64, error) {
+	cols, err := checkRectangular("Diagonal", matrix)
+	if err != nil {
+		return nil, err
+	}
+	var diagonal []float64
+	for i := max(0, -k); i < len(matrix) && i+k < cols; i++ {
+		diagonal = append(diagonal, matrix[i][i+k])
+	}
+	return diagonal, nil
+}
+
+// identity returns the n×n identity matrix.
+func identity(n int) [][]float64 {
+	matrix := newMatrix(n, n)
+	for i := range matrix {
+		matrix[i][i] = 1
+	}
+	return matrix
+}
+
+// checkDimensions checks that the requested matrix has at least one row and one column.
+func checkDimensions(op string, rows, cols int) error {
+	if rows <= 0 || cols <= 0 {
+		return argumentError(op, "matrix dimensions must be positive, got %dx%d", rows, cols)
+	}
+	return nil
+}
+
+// checkBlocks checks that at least one matrix is given and that each is a valid non-empty matrix,
+// returning their widths.
+func checkBlocks(op string, matrices [][][]float64) ([]int, error) {
+	if len(matrices) == 0 {
+		return nil, emptyError(op, nil, "at least one matrix is required")
+	}
+	widths := make([]int, len(matrices))
+	for i, matrix := range matrices {
+		width, err := checkRectangular(op, matrix)
+		if err != nil {
+			return nil, err
+		}
+		widths[i] = width
+	}
+	return widths, nil
+}
+
+// blockShapes describes a list of matrices for use in errors.
+func blockShapes(matrices [][][]float64) [][]int {
+	shapes := make([][]int, len(matrices))
+	for i, matrix := range matrices {
+		shapes[i] = matrixShapes(matrix)[0]
+	}
+	return shapes
+}
@@ -0,0 +1,131 @@
+package litearray
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBasicConstructors(t *testing.T) {
+	// Test case 1: Identity, zeros, ones and constant matrices
+	identity, _ := Identity(2)
+	zeros, _ := Zeros(1, 2)
+	ones, _ := Ones(2, 1)
+	full, _ := Full(1, 2, 7)
+	if !compareMatrices(identity, [][]float64{{1, 0}, {0, 1}}, 0) || !compareMatrices(zeros, [][]float64{{0, 0}}, 0) ||
+		!compareMatrices(ones, [][]float64{{1}, {1}}, 0) || !compareMatrices(full, [][]float64{{7, 7}}, 0) {
+		t.Errorf("Unexpected matrices %v, %v, %v and %v", identity, zeros, ones, full)
+	}
+
+	// Test case 2: Offset diagonals
+	eye, _ := Eye(2, 3, 1)
+	if !compareMatrices(eye, [][]float64{{0, 1, 0}, {0, 0, 1}}, 0) {
+		t.Errorf("Expected [[0 1 0] [0 0 1]], got %v", eye)
+	}
+	diag, _ := Diag([]float64{1, 2}, -1)
+	if !compareMatrices(diag, [][]float64{{0, 0, 0}, {1, 0, 0}, {0, 2, 0}}, 0) {
+		t.Errorf("Expected [[0 0 0] [1 0 0] [0 2 0]], got %v", diag)
+	}
+
+	// Test case 3: Invalid dimensions
+	if _, err := Zeros(0, 2); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := Diag(nil, 0); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestStructuredConstructors(t *testing.T) {
+	// Test case 1: Toeplitz, with and without a separate first row
+	toeplitz, _ := Toeplitz([]float64{1, 2, 3}, []float64{9, 4, 5})
+	if !compareMatrices(toeplitz, [][]float64{{1, 4, 5}, {2, 1, 4}, {3, 2, 1}}, 0) {
+		t.Errorf("Expected [[1 4 5] [2 1 4] [3 2 1]], got %v", toeplitz)
+	}
+	toeplitz, _ = Toeplitz([]float64{1, 2}, nil)
+	if !compareMatrices(toeplitz, [][]float64{{1, 2}, {2, 1}}, 0) {
+		t.Errorf("Expected [[1 2] [2 1]], got %v", toeplitz)
+	}
+
+	// Test case 2: Hankel, with and without a last row
+	hankel, _ := Hankel([]float64{1, 2, 3}, []float64{9, 4, 5})
+	if !compareMatrices(hankel, [][]float64{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, 0) {
+		t.Errorf("Expected [[1 2 3] [2 3 4] [3 4 5]], got %v", hankel)
+	}
+	hankel, _ = Hankel([]float64{1, 2, 3}, nil)
+	if !compareMatrices(hankel, [][]float64{{1, 2, 3}, {2, 3, 0}, {3, 0, 0}}, 0) {
+		t.Errorf("Expected [[1 2 3] [2 3 0] [3 0 0]], got %v", hankel)
+	}
+
+	// Test case 3: Vandermonde, Hilbert and circulant
+	vandermonde, _ := Vandermonde([]float64{2, 3}, 3)
+	hilbert, _ := Hilbert(2)
+	circulant, _ := Circulant([]float64{1, 2, 3})
+	if !compareMatrices(vandermonde, [][]float64{{1, 2, 4}, {1, 3, 9}}, 0) ||
+		!compareMatrices(hilbert, [][]float64{{1, 0.5}, {0.5, 1.0 / 3}}, 0) ||
+		!compareMatrices(circulant, [][]float64{{1, 3, 2}, {2, 1, 3}, {3, 2, 1}}, 0) {
+		t.Errorf("Unexpected matrices %v, %v and %v", vandermonde, hilbert, circulant)
+	}
+}
+
+func TestBlockOperations(t *testing.T) {
+	a := [][]float64{{1, 2}, {3, 4}}
+	b := [][]float64{{5}, {6}}
+
+	// Test case 1: Horizontal and vertical stacking
+	h, err := HStack(a, b)
+	if err != nil || !compareMatrices(h, [][]float64{{1, 2, 5}, {3, 4, 6}}, 0) {
+		t.Errorf("Expected [[1 2 5] [3 4 6]], got %v (err %v)", h, err)
+	}
+	v, err := VStack(a, [][]float64{{7, 8}})
+	if err != nil || !compareMatrices(v, [][]float64{{1, 2}, {3, 4}, {7, 8}}, 0) {
+		t.Errorf("Expected [[1 2] [3 4] [7 8]], got %v (err %v)", v, err)
+	}
+
+	// Test case 2: Block diagonal of non-square blocks
+	blocks, err := BlockDiag(a, b)
+	if err != nil || !compareMatrices(blocks, [][]float64{{1, 2, 0}, {3, 4, 0}, {0, 0, 5}, {0, 0, 6}}, 0) {
+		t.Errorf("Expected a 4x3 block diagonal matrix, got %v (err %v)", blocks, err)
+	}
+
+	// Test case 3: Kronecker product
+	kron, err := Kron([][]float64{{1, 2}}, [][]float64{{1, 0}, {0, 1}})
+	if err != nil || !compareMatrices(kron, [][]float64{{1, 0, 2, 0}, {0, 1, 0, 2}}, 0) {
+		t.Errorf("Expected [[1 0 2 0] [0 1 0 2]], got %v (err %v)", kron, err)
+	}
+
+	// Test case 4: Mismatched blocks
+	if _, err := HStack(a, [][]float64{{1}}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := VStack(a, b); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := BlockDiag(); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestTriangularAndDiagonal(t *testing.T) {
+	matrix := [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+
+	// Test case 1: Triangles with offsets
+	lower, _ := Tril(matrix, 0)
+	upper, _ := Triu(matrix, 1)
+	if !compareMatrices(lower, [][]float64{{1, 0, 0}, {4, 5, 0}, {7, 8, 9}}, 0) ||
+		!compareMatrices(upper, [][]float64{{0, 2, 3}, {0, 0, 6}, {0, 0, 0}}, 0) {
+		t.Errorf("Unexpected triangles %v and %v", lower, upper)
+	}
+
+	// Test case 2: Diagonals with offsets, including one outside the matrix
+	main, _ := Diagonal(matrix, 0)
+	below, _ := Diagonal(matrix, -2)
+	outside, _ := Diagonal(matrix, 5)
+	if !compareSlices(main, []float64{1, 5, 9}, 0) || !compareSlices(below, []float64{7}, 0) || len(outside) != 0 {
+		t.Errorf("Unexpected diagonals %v, %v and %v", main, below, outside)
+	}
+
+	// Test case 3: The input is not modified
+	if matrix[0][1] != 2 {
+		t.Errorf("Expected the input to be left alone, got %v", matrix)
+	}
+}
@@ -0,0 +1,337 @@
+package litearray
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// Covariance calculates the covariance matrix of variables, arrays of equal length holding one
+// observation of each variable per position. Entry (i, j) is Σ wₖ(xᵢₖ − x̄ᵢ)(xⱼₖ − x̄ⱼ) divided
+// by N − ddof, with unit weights unless WithWeights gives others. ddof defaults to 0, so the
+// diagonal matches Variance; WithDDOF(1) gives the unbiased sample covariance as in NumPy's cov.
+// Under NaNOmit observations in which any variable is NaN are dropped. With WithOut the rows of
+// the result share the given buffer, which must hold k × k elements for k variables.
+func Covariance(variables [][]float64, opts ...Option) ([][]float64, error) {
+	s, err := newCorrelation("Covariance", variables, opts, true)
+	if err != nil {
+		return nil, err
+	}
+	s.covariance(s.matrix, s.data, s.weights)
+	return s.finish(), nil
+}
+
+// CorrelationMatrix calculates the Pearson correlation matrix of variables, laid out as for
+// Covariance, with entries clamped to [−1, 1]. Correlations involving a constant variable are
+// NaN. It accepts the options of Covariance, though ddof cancels out.
+func CorrelationMatrix(variables [][]float64, opts ...Option) ([][]float64, error) {
+	s, err := newCorrelation("CorrelationMatrix", variables, opts, true)
+	if err != nil {
+		return nil, err
+	}
+	s.covariance(s.matrix, s.data, s.weights)
+	normalize(s.matrix)
+	return s.finish(), nil
+}
+
+// SpearmanCorrelation calculates the Spearman rank correlation matrix of variables: the Pearson
+// correlation of their ranks, with tied values sharing the average of their ranks. It measures
+// how well each pair is described by a monotonic relationship and resists outliers. Weights, if
+// given, weight the Pearson correlation of the ranks.
+func SpearmanCorrelation(variables [][]float64, opts ...Option) ([][]float64, error) {
+	s, err := newCorrelation("SpearmanCorrelation", variables, opts, true)
+	if err != nil {
+		return nil, err
+	}
+	ranks := make([][]float64, len(s.data))
+	for i, x := range s.data {
+		ranks[i] = averageRanks(x)
+	}
+	s.covariance(s.matrix, ranks, s.weights)
+	normalize(s.matrix)
+	return s.finish(), nil
+}
+
+// KendallCorrelation calculates the Kendall rank correlation matrix of variables, using the τ-b
+// statistic that corrects for ties. Each pair takes O(N log N) time with Knight's algorithm.
+// Weights are not supported, and ddof does not apply.
+func KendallCorrelation(variables [][]float64, opts ...Option) ([][]float64, error) {
+	s, err := newCorrelation("KendallCorrelation", variables, opts, false)
+	if err != nil {
+		return nil, err
+	}
+	for i := range s.data {
+		for j := i; j < len(s.data); j++ {
+			s.matrix[i][j] = kendallTau(s.data[i], s.data[j])
+			s.matrix[j][i] = s.matrix[i][j]
+		}
+	}
+	return s.finish(), nil
+}
+
+// correlation holds a validated covariance or correlation call: the observations and weights to
+// use and the k×k result, both as rows and as the flat buffer behind them.
+type correlation struct {
+	*config
+	op      string
+	shapes  [][]int
+	data    [][]float64
+	weights []float64
+	buffer  []float64
+	matrix  [][]float64
+}
+
+// newCorrelation validates the variables and options of a covariance or correlation, checking
+// that ddof leaves degrees of freedom when dof is set. Under NaNOmit the observations in which
+// any variable is NaN are dropped from copies of the variables.
+func newCorrelation(op string, variables [][]float64, opts []Option, dof bool) (*correlation, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(variables) == 0 {
+		return nil, emptyError(op, nil, "at least one variable is required")
+	}
+	if err := sameLength(op, variables); err != nil {
+		return nil, err
+	}
+	n := len(variables[0])
+	if n == 0 {
+		return nil, emptyError(op, shapesOf(variables...), "variables cannot be empty")
+	}
+	if c.weights != nil {
+		if err := checkWeights(op, c.weights, n); err != nil {
+			return nil, err
+		}
+	}
+	if dof && !(c.weightedDenominator(c.weights, n) > 0) {
+		return nil, argumentError(op, "%d observations leave no degrees of freedom with ddof %d", n, c.ddof)
+	}
+	if err := c.checkNaN(op, variables...); err != nil {
+		return nil, err
+	}
+
+	k := len(variables)
+	buffer, err := c.result(op, k*k)
+	if err != nil {
+		return nil, err
+	}
+	s := &correlation{config: c, op: op, shapes: shapesOf(variables...), data: variables, weights: c.weights, buffer: buffer, matrix: rowsOf(buffer, k, k)}
+	if !c.omitNaN() {
+		return s, nil
+	}
+
+	// Keep only the observations complete in every variable
+	var keep []int
+	for o := 0; o < n; o++ {
+		complete := true
+		for _, x := range variables {
+			if math.IsNaN(x[o]) {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			keep = append(keep, o)
+		}
+	}
+	s.data = make([][]float64, k)
+	for i, x := range variables {
+		s.data[i] = make([]float64, len(keep))
+		for j, o := range keep {
+			s.data[i][j] = x[o]
+		}
+	}
+	if c.weights != nil {
+		s.weights = make([]float64, len(keep))
+		for j, o := range keep {
+			s.weights[j] = c.weights[o]
+		}
+	}
+	return s, nil
+}
+
+// finish rounds the result and returns its rows.
+func (s *correlation) finish() [][]float64 {
+	// Apply rounding if precision is non-negative
+	s.round(s.op, s.shapes, s.buffer)
+
+	return s.matrix
+}
+
+// covariance writes the covariance matrix of data to dst. It is NaN when too few observations
+// remain for the degrees of freedom.
+func (c *config) covariance(dst, data [][]float64, weights []float64) {
+	n := len(data[0])
+	denominator := c.weightedDenominator(weights, n)
+	if n == 0 || (weights != nil && floats.Sum(weights) == 0) || !(denominator > 0) {
+		for _, row := range dst {
+			for j := range row {
+				row[j] = math.NaN()
+			}
+		}
+		return
+	}
+
+	// Center each variable on its weighted mean, keeping a weighted copy for the products
+	centered, weighted := make([][]float64, len(data)), make([][]float64, len(data))
+	for i, x := range data {
+		mean := 0.0
+		if weights == nil {
+			mean = floats.Sum(x) / float64(n)
+		} else {
+			mean = floats.Dot(weights, x) / floats.Sum(weights)
+		}
+		centered[i] = append([]float64(nil), x...)
+		floats.AddConst(-mean, centered[i])
+		weighted[i] = centered[i]
+		if weights != nil {
+			weighted[i] = floats.MulTo(make([]float64, n), weights, centered[i])
+		}
+	}
+
+	for i := range data {
+		for j := i; j < len(data); j++ {
+			dst[i][j] = floats.Dot(weighted[i], centered[j]) / denominator
+			dst[j][i] = dst[i][j]
+		}
+	}
+}
+
+// normalize turns a covariance matrix into a correlation matrix in place. The diagonal is exactly
+// 1 for every variable that varies.
+func normalize(matrix [][]float64) {
+	k := len(matrix)
+	scale := make([]float64, k)
+	for i := range scale {
+		scale[i] = math.Sqrt(matrix[i][i])
+	}
+	for i, row := range matrix {
+		for j := range row {
+			row[j] = max(-1, min(1, row[j]/(scale[i]*scale[j])))
+		}
+		if scale[i] > 0 {
+			row[i] = 1
+		}
+	}
+}
+
+// averageRanks returns the ranks of values from 1 to n, giving tied values the average of the
+// ranks they span. A NaN anywhere makes every rank NaN.
+func averageRanks(values []float64) []float64 {
+	ranks := make([]float64, len(values))
+	for _, v := range values {
+		if math.IsNaN(v) {
+			for i := range ranks {
+				ranks[i] = math.NaN()
+			}
+			return ranks
+		}
+	}
+
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return values[order[a]] < values[order[b]] })
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && values[order[end]] == values[order[start]] {
+			end++
+		}
+		rank := float64(start+end+1) / 2
+		for _, i := range order[start:end] {
+			ranks[i] = rank
+		}
+		start = end
+	}
+	return ranks
+}
+
+// kendallTau returns Kendall's τ-b of x and y by Knight's algorithm: sort the pairs by x, then
+// count the discordant pairs as the swaps a merge sort by y makes. It is NaN if either holds NaN
+// or is constant.
+func kendallTau(x, y []float64) float64 {
+	n := len(x)
+	for i := range x {
+		if math.IsNaN(x[i]) || math.IsNaN(y[i]) {
+			return math.NaN()
+		}
+	}
+
+	// Sort by x, breaking ties by y, and count the pairs tied in x and in both
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		return x[i] < x[j] || (x[i] == x[j] && y[i] < y[j])
+	})
+	xTies, jointTies := 0, 0
+	for start := 0; start < n; {
+		end, joint := start+1, start
+		for end < n && x[order[end]] == x[order[start]] {
+			if y[order[end]] != y[order[joint]] {
+				jointTies += pairs(end - joint)
+				joint = end
+			}
+			end++
+		}
+		jointTies += pairs(end - joint)
+		xTies += pairs(end - start)
+		start = end
+	}
+
+	// Merge sort y in that order, counting the swaps, then count the pairs tied in y
+	ys := make([]float64, n)
+	for k, i := range order {
+		ys[k] = y[i]
+	}
+	swaps := mergeCount(ys, make([]float64, n))
+	yTies := 0
+	for start := 0; start < n; {
+		end := start + 1
+		for end < n && ys[end] == ys[start] {
+			end++
+		}
+		yTies += pairs(end - start)
+		start = end
+	}
+
+	total := pairs(n)
+	return float64(total-xTies-yTies+jointTies-2*swaps) / math.Sqrt(float64(total-xTies)*float64(total-yTies))
+}
+
+// mergeCount sorts values by merge sort and returns the number of pairs it had out of order,
+// not counting equal values. buffer must be as long as values.
+func mergeCount(values, buffer []float64) int {
+	n := len(values)
+	if n < 2 {
+		return 0
+	}
+	mid := n / 2
+	swaps := mergeCount(values[:mid], buffer[:mid]) + mergeCount(values[mid:], buffer[mid:])
+	i, j, k := 0, mid, 0
+	for i < mid && j < n {
+		if values[i] <= values[j] {
+			buffer[k] = values[i]
+			i++
+		} else {
+			buffer[k] = values[j]
+			swaps += mid - i
+			j++
+		}
+		k++
+	}
+	k += copy(buffer[k:], values[i:mid])
+	copy(buffer[k:], values[j:n])
+	copy(values, buffer)
+	return swaps
+}
+
+// pairs returns the number of unordered pairs among n items.
+func pairs(n int) int {
+	return n * (n - 1) / 2
+}
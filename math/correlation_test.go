@@ -0,0 +1,148 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCovarianceAndPearson(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2, 4, 5, 4, 5}
+
+	// Test case 1: Population and sample covariance, whose diagonal matches Variance
+	result, err := Covariance([][]float64{x, y})
+	if err != nil || !compareMatrices(result, [][]float64{{2, 1.2}, {1.2, 1.2}}, 1e-12) {
+		t.Errorf("Expected [[2 1.2] [1.2 1.2]], got %v (err %v)", result, err)
+	}
+	result, _ = Covariance([][]float64{x, y}, WithDDOF(1))
+	if !compareMatrices(result, [][]float64{{2.5, 1.5}, {1.5, 1.5}}, 1e-12) {
+		t.Errorf("Expected [[2.5 1.5] [1.5 1.5]], got %v", result)
+	}
+
+	// Test case 2: Pearson correlation, with rounding and a caller's buffer
+	out := make([]float64, 4)
+	result, err = CorrelationMatrix([][]float64{x, y}, WithPrecision(4), WithOut(out))
+	if err != nil || !compareMatrices(result, [][]float64{{1, 0.7746}, {0.7746, 1}}, 0) || out[1] != 0.7746 {
+		t.Errorf("Expected [[1 0.7746] [0.7746 1]] in the buffer, got %v (err %v)", result, err)
+	}
+
+	// Test case 3: Frequency weights count repeated observations
+	weighted, _ := Covariance([][]float64{x, y}, WithWeights([]float64{1, 3, 1, 1, 1}, FrequencyWeights), WithDDOF(1))
+	repeated, _ := Covariance([][]float64{{1, 2, 2, 2, 3, 4, 5}, {2, 4, 4, 4, 5, 4, 5}}, WithDDOF(1))
+	if !compareMatrices(weighted, repeated, 1e-12) {
+		t.Errorf("Expected %v, got %v", repeated, weighted)
+	}
+
+	// Test case 4: Only the ratios of reliability weights matter
+	weighted, _ = Covariance([][]float64{x, y}, WithWeights([]float64{2, 2, 2, 2, 2}, ReliabilityWeights), WithDDOF(1))
+	if !compareMatrices(weighted, [][]float64{{2.5, 1.5}, {1.5, 1.5}}, 1e-12) {
+		t.Errorf("Expected [[2.5 1.5] [1.5 1.5]], got %v", weighted)
+	}
+	weighted, _ = Covariance([][]float64{x}, WithWeights([]float64{1, 0, 0, 0, 1}, ReliabilityWeights), WithDDOF(1))
+	if !compareMatrices(weighted, [][]float64{{8}}, 1e-12) {
+		t.Errorf("Expected [[8]], got %v", weighted)
+	}
+
+	// Test case 5: A constant variable correlates as NaN; NaN propagates or is omitted listwise
+	result, _ = CorrelationMatrix([][]float64{x, {3, 3, 3, 3, 3}})
+	if !math.IsNaN(result[0][1]) || !math.IsNaN(result[1][1]) || result[0][0] != 1 {
+		t.Errorf("Expected NaN correlations with a constant, got %v", result)
+	}
+	result, _ = CorrelationMatrix([][]float64{{1, 2, math.NaN(), 4}, {2, 4, 1, 8}}, WithNaNPolicy(NaNOmit))
+	if !compareMatrices(result, [][]float64{{1, 1}, {1, 1}}, 1e-12) {
+		t.Errorf("Expected [[1 1] [1 1]], got %v", result)
+	}
+
+	// Test case 6: Invalid input
+	if _, err := Covariance([][]float64{x, {1, 2}}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	for _, variables := range [][][]float64{nil, {{}}} {
+		if _, err := Covariance(variables); !errors.Is(err, ErrEmptyInput) {
+			t.Errorf("Expected ErrEmptyInput for %v, got %v", variables, err)
+		}
+	}
+	if _, err := Covariance([][]float64{{1}}, WithDDOF(1)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := Covariance([][]float64{x}, WithWeights([]float64{1, -1, 1, 1, 1}, FrequencyWeights)); !errors.Is(err, ErrDomain) {
+		t.Errorf("Expected ErrDomain, got %v", err)
+	}
+	if _, err := Covariance([][]float64{x}, WithWeights([]float64{0, 0, 0, 0, 0}, FrequencyWeights)); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v", err)
+	}
+	if _, err := Covariance([][]float64{x}, WithWeights([]float64{1, 1}, FrequencyWeights)); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
+
+func TestRankCorrelation(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2, 4, 5, 4, 5}
+
+	// Test case 1: Spearman correlation with tied ranks, against SciPy's spearmanr
+	result, err := SpearmanCorrelation([][]float64{x, y})
+	if err != nil || !compareMatrices(result, [][]float64{{1, 0.7378647873726218}, {0.7378647873726218, 1}}, 1e-12) {
+		t.Errorf("Unexpected Spearman correlation %v (err %v)", result, err)
+	}
+
+	// Test case 2: Kendall's τ-b with ties, against SciPy's kendalltau
+	result, err = KendallCorrelation([][]float64{x, y})
+	if err != nil || !compareMatrices(result, [][]float64{{1, 0.6708203932499369}, {0.6708203932499369, 1}}, 1e-12) {
+		t.Errorf("Unexpected Kendall correlation %v (err %v)", result, err)
+	}
+
+	// Test case 3: Both are ±1 for any monotonic relationship
+	cubes := []float64{1, 8, 27, 64, 125}
+	reversed := []float64{5, 4, 3, 2, 1}
+	for _, rank := range []func([][]float64, ...Option) ([][]float64, error){SpearmanCorrelation, KendallCorrelation} {
+		result, _ = rank([][]float64{x, cubes, reversed})
+		want := [][]float64{{1, 1, -1}, {1, 1, -1}, {-1, -1, 1}}
+		if !compareMatrices(result, want, 1e-12) {
+			t.Errorf("Expected %v, got %v", want, result)
+		}
+	}
+
+	// Test case 4: Knight's algorithm agrees with counting every pair
+	a := []float64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5, 8, 9, 7, 9}
+	b := []float64{2, 7, 1, 8, 2, 8, 1, 8, 2, 8, 4, 5, 9, 0, 4}
+	concordant, discordant, aTies, bTies, total := 0, 0, 0, 0, 0
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			total++
+			switch s := (a[i] - a[j]) * (b[i] - b[j]); {
+			case s > 0:
+				concordant++
+			case s < 0:
+				discordant++
+			}
+			if a[i] == a[j] {
+				aTies++
+			}
+			if b[i] == b[j] {
+				bTies++
+			}
+		}
+	}
+	want := float64(concordant-discordant) / math.Sqrt(float64(total-aTies)*float64(total-bTies))
+	if got := kendallTau(a, b); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Test case 5: NaN propagates to the variable's row and column; Kendall rejects weights
+	result, _ = KendallCorrelation([][]float64{x, {1, math.NaN(), 3, 4, 5}})
+	if result[0][0] != 1 || !math.IsNaN(result[0][1]) || !math.IsNaN(result[1][1]) {
+		t.Errorf("Expected NaN for the second variable, got %v", result)
+	}
+	if _, err := KendallCorrelation([][]float64{x, y}, WithWeights([]float64{1, 1, 1, 1, 1}, FrequencyWeights)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+
+	// Test case 6: ddof does not affect τ, so any ddof is accepted
+	plain, _ := KendallCorrelation([][]float64{x, y})
+	result, err = KendallCorrelation([][]float64{x, y}, WithDDOF(len(x)))
+	if err != nil || !compareMatrices(result, plain, 0) {
+		t.Errorf("Expected %v, got %v (err %v)", plain, result, err)
+	}
+}
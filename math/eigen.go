@@ -0,0 +1,216 @@
+package litearray
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// EigenKind selects which eigenvectors Eigen computes besides the eigenvalues.
+type EigenKind int
+
+const (
+	// EigenNone computes only the eigenvalues.
+	EigenNone EigenKind = 0
+	// EigenRight computes the right eigenvectors, which satisfy A·v = λ·v.
+	EigenRight EigenKind = 1 << 0
+	// EigenLeft computes the left eigenvectors, which satisfy uᴴ·A = λ·uᴴ.
+	EigenLeft EigenKind = 1 << 1
+	// EigenBoth computes both left and right eigenvectors.
+	EigenBoth = EigenLeft | EigenRight
+)
+
+// EigenResult holds the eigen decomposition of a square matrix. Eigenvectors are stored as
+// columns with unit 2-norm: column k of Right and Left belongs to Values[k].
+type EigenResult struct {
+	// Values holds the eigenvalues ordered by decreasing real part, then decreasing imaginary
+	// part, so complex conjugate pairs are adjacent.
+	Values []complex128
+	// Right holds the right eigenvectors, or nil unless EigenRight was requested.
+	Right [][]complex128
+	// Left holds the left eigenvectors, or nil unless EigenLeft was requested.
+	Left [][]complex128
+}
+
+// Eigen computes the eigenvalues of an n×n matrix for any n ≥ 1, and the eigenvectors selected
+// by kind. Results are always complex, even when they happen to be real, and are rounded
+// according to opts. For symmetric matrices EigenSymmetric guarantees real results.
+func Eigen(matrix [][]float64, kind EigenKind, opts ...Option) (*EigenResult, error) {
+	return eigen("Eigen", matrix, kind, opts)
+}
+
+func eigen(op string, matrix [][]float64, kind EigenKind, opts []Option) (*EigenResult, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if kind < EigenNone || kind > EigenBoth {
+		return nil, argumentError(op, "unknown eigen kind %d", int(kind))
+	}
+	if err := checkSquare(op, matrix); err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return nil, err
+	}
+
+	gonumKind := mat.EigenNone
+	if kind&EigenRight != 0 {
+		gonumKind |= mat.EigenRight
+	}
+	if kind&EigenLeft != 0 {
+		gonumKind |= mat.EigenLeft
+	}
+
+	// Compute the decomposition using Gonum's Eigen method
+	var eig mat.Eigen
+	if !eig.Factorize(denseOf(matrix), gonumKind) {
+		return nil, &ConvergenceError{Op: op, Residual: math.NaN(), msg: "failed to compute eigenvalues"}
+	}
+	values := eig.Values(nil)
+
+	// Order the eigenvalues so results do not depend on the algorithm
+	n := len(matrix)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := values[order[i]], values[order[j]]
+		if real(a) != real(b) {
+			return real(a) > real(b)
+		}
+		return imag(a) > imag(b)
+	})
+
+	result := &EigenResult{Values: make([]complex128, n)}
+	for k, i := range order {
+		result.Values[k] = values[i]
+	}
+	c.roundComplex(op, matrixShapes(matrix), result.Values)
+
+	if kind&EigenRight != 0 {
+		var vectors mat.CDense
+		eig.VectorsTo(&vectors)
+		result.Right = reorderColumns(&vectors, order)
+		for _, row := range result.Right {
+			c.roundComplex(op, matrixShapes(matrix), row)
+		}
+	}
+	if kind&EigenLeft != 0 {
+		var vectors mat.CDense
+		eig.LeftVectorsTo(&vectors)
+		result.Left = reorderColumns(&vectors, order)
+		for _, row := range result.Left {
+			c.roundComplex(op, matrixShapes(matrix), row)
+		}
+	}
+
+	return result, nil
+}
+
+// EigenSymmetric computes the eigenvalues and eigenvectors of a real symmetric n×n matrix, such as
+// a covariance matrix in PCA. The eigenvalues are real and sorted in decreasing order, and the
+// eigenvectors are real, orthonormal and stored as columns: column k of vectors belongs to
+// values[k]. The matrix must be symmetric to within the tolerance, which defaults to
+// √ε·max|aᵢⱼ| and can be set with WithTolerance. Results are rounded according to opts.
+func EigenSymmetric(matrix [][]float64, opts ...Option) (values []float64, vectors [][]float64, err error) {
+	const op = "EigenSymmetric"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.checkSymmetric(op, matrix); err != nil {
+		return nil, nil, err
+	}
+
+	// Gonum reads the upper triangle and returns the eigenvalues in increasing order
+	n := len(matrix)
+	sym := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			sym.SetSym(i, j, matrix[i][j])
+		}
+	}
+	var eig mat.EigenSym
+	if !eig.Factorize(sym, true) {
+		return nil, nil, &ConvergenceError{Op: op, Residual: math.NaN(), msg: "failed to compute eigenvalues"}
+	}
+	ascending := eig.Values(nil)
+	var dense mat.Dense
+	eig.VectorsTo(&dense)
+
+	// Reverse into decreasing order
+	data := make([]float64, n*n)
+	vectors = rowsOf(data, n, n)
+	values = make([]float64, n)
+	for k := 0; k < n; k++ {
+		values[k] = ascending[n-1-k]
+		for i := 0; i < n; i++ {
+			vectors[i][k] = dense.At(i, n-1-k)
+		}
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), values)
+	c.round(op, matrixShapes(matrix), data)
+
+	return values, vectors, nil
+}
+
+// checkSymmetric checks that matrix is square, free of NaN under NaNRaise and symmetric to within
+// the call's tolerance, which defaults to √ε·max|aᵢⱼ|.
+func (c *config) checkSymmetric(op string, matrix [][]float64) error {
+	if err := checkSquare(op, matrix); err != nil {
+		return err
+	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return err
+	}
+
+	largest := 0.0
+	for _, row := range matrix {
+		for _, v := range row {
+			largest = max(largest, math.Abs(v))
+		}
+	}
+	tolerance := c.toleranceOr(math.Sqrt(epsilon) * largest)
+	for i := range matrix {
+		for j := i + 1; j < len(matrix); j++ {
+			if math.Abs(matrix[i][j]-matrix[j][i]) > tolerance {
+				return argumentError(op, "matrix is not symmetric: entries (%d, %d) and (%d, %d) differ", i, j, j, i)
+			}
+		}
+	}
+	return nil
+}
+
+// roundComplex rounds the real and imaginary parts of values in place.
+func (c *config) roundComplex(op string, shapes [][]int, values []complex128) {
+	if c.precision < 0 {
+		return
+	}
+	parts := make([]float64, 2*len(values))
+	for i, v := range values {
+		parts[2*i], parts[2*i+1] = real(v), imag(v)
+	}
+	c.round(op, shapes, parts)
+	for i := range values {
+		values[i] = complex(parts[2*i], parts[2*i+1])
+	}
+}
+
+// reorderColumns copies the columns of m into rows of complex values, taking column order[k] as
+// column k.
+func reorderColumns(m *mat.CDense, order []int) [][]complex128 {
+	rows, cols := m.Dims()
+	result := make([][]complex128, rows)
+	for i := range result {
+		result[i] = make([]complex128, cols)
+		for k, j := range order {
+			result[i][k] = m.At(i, j)
+		}
+	}
+	return result
+}
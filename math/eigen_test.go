@@ -0,0 +1,115 @@
+package litearray
+
+import (
+	"errors"
+	"math/cmplx"
+	"testing"
+)
+
+// checkEigenpairs reports whether every column of vectors satisfies A·v = λ·v, or uᴴ·A = λ·uᴴ
+// when left is set.
+func checkEigenpairs(matrix [][]float64, values []complex128, vectors [][]complex128, left bool) bool {
+	n := len(matrix)
+	for k, lambda := range values {
+		for i := 0; i < n; i++ {
+			var product complex128
+			for j := 0; j < n; j++ {
+				if left {
+					product += cmplx.Conj(vectors[j][k]) * complex(matrix[j][i], 0)
+				} else {
+					product += complex(matrix[i][j], 0) * vectors[j][k]
+				}
+			}
+			expected := lambda * vectors[i][k]
+			if left {
+				expected = lambda * cmplx.Conj(vectors[i][k])
+			}
+			if cmplx.Abs(product-expected) > 1e-10 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestEigen(t *testing.T) {
+	// Test case 1: 1x1 matrix
+	result, err := Eigen([][]float64{{5}}, EigenNone)
+	if err != nil || !compareComplexSlices(result.Values, []complex128{5}, 0) || result.Right != nil {
+		t.Errorf("Expected [5] without vectors, got %+v (err %v)", result, err)
+	}
+
+	// Test case 2: Complex eigenvalues of a 2x2 rotation are returned, not rejected
+	result, err = Eigen([][]float64{{0, -1}, {1, 0}}, EigenNone)
+	if err != nil || !compareComplexSlices(result.Values, []complex128{1i, -1i}, 1e-12) {
+		t.Errorf("Expected [i -i], got %+v (err %v)", result, err)
+	}
+
+	// Test case 3: Left and right eigenvectors
+	matrix := [][]float64{{4, 2, 1}, {1, 3, 2}, {2, 1, 5}}
+	result, err = Eigen(matrix, EigenBoth)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !checkEigenpairs(matrix, result.Values, result.Right, false) {
+		t.Errorf("Expected A·v = λ·v, got %+v", result)
+	}
+	if !checkEigenpairs(matrix, result.Values, result.Left, true) {
+		t.Errorf("Expected uᴴ·A = λ·uᴴ, got %+v", result)
+	}
+
+	// Test case 4: Rounding applies to both parts
+	result, err = Eigen(matrix, EigenNone, WithPrecision(2))
+	if err != nil || result.Values[1] != complex(2.43, 0.63) {
+		t.Errorf("Expected 2.43+0.63i, got %+v (err %v)", result, err)
+	}
+
+	// Test case 5: Invalid input
+	_, err = Eigen([][]float64{{1, 2}}, EigenRight)
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	_, err = Eigen(matrix, EigenKind(8))
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestEigenSymmetric(t *testing.T) {
+	covariance := [][]float64{{4, 1, 0}, {1, 3, 1}, {0, 1, 2}}
+
+	// Test case 1: Real, decreasing eigenvalues with orthonormal eigenvectors
+	values, vectors, err := EigenSymmetric(covariance)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !(values[0] >= values[1] && values[1] >= values[2]) {
+		t.Errorf("Expected decreasing eigenvalues, got %v", values)
+	}
+	if !compareMatrices(multiplyMatrices(transposeMatrix(vectors), vectors), identityMatrix(3), 1e-12) {
+		t.Errorf("Expected orthonormal eigenvectors, got %v", vectors)
+	}
+	for k, lambda := range values {
+		for i := range covariance {
+			product := 0.0
+			for j := range covariance {
+				product += covariance[i][j] * vectors[j][k]
+			}
+			if diff := product - lambda*vectors[i][k]; diff > 1e-12 || diff < -1e-12 {
+				t.Errorf("Expected A·v = λ·v for eigenvalue %v", lambda)
+			}
+		}
+	}
+
+	// Test case 2: A simple 2x2 covariance matrix
+	values, _, err = EigenSymmetric([][]float64{{2, 1}, {1, 2}}, WithPrecision(10))
+	if err != nil || !compareSlices(values, []float64{3, 1}, 0) {
+		t.Errorf("Expected [3 1], got %v (err %v)", values, err)
+	}
+
+	// Test case 3: Non-symmetric matrices are rejected
+	_, _, err = EigenSymmetric([][]float64{{1, 2}, {3, 4}})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
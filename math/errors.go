@@ -0,0 +1,143 @@
+package litearray
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors that classify every failure reported by the package. Use errors.Is to test
+// for them; the structured error types below wrap exactly one of them.
+var (
+	// ErrShapeMismatch reports operands whose lengths or shapes are incompatible.
+	ErrShapeMismatch = errors.New("shape mismatch")
+	// ErrEmptyInput reports missing, nil or empty arrays and matrices.
+	ErrEmptyInput = errors.New("empty input")
+	// ErrDivideByZero reports a division or modulo by zero.
+	ErrDivideByZero = errors.New("division by zero")
+	// ErrSingular reports a matrix that cannot be inverted or factorized.
+	ErrSingular = errors.New("singular matrix")
+	// ErrDomain reports a value outside the domain of the operation, such as the logarithm of a negative number.
+	ErrDomain = errors.New("value outside the domain of the operation")
+	// ErrPrecisionRange reports a precision outside the supported range.
+	ErrPrecisionRange = errors.New("precision out of range")
+	// ErrInvalidArgument reports any other argument the operation cannot accept, such as an out of range axis or percentile.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrNoConvergence reports an iterative algorithm that failed to converge.
+	ErrNoConvergence = errors.New("no convergence")
+	// ErrOverflow reports an integer result that does not fit its element type.
+	ErrOverflow = errors.New("integer overflow")
+	// ErrNotPositiveDefinite reports a matrix that a Cholesky factorization requires to be positive definite.
+	ErrNotPositiveDefinite = errors.New("matrix is not positive definite")
+)
+
+// Precision limits accepted by every function that rounds its results. A precision of -1 disables rounding.
+const (
+	MinPrecision = -1
+	MaxPrecision = 10
+)
+
+// ShapeError reports operands that are empty or whose shapes are incompatible. Err is either
+// ErrShapeMismatch or ErrEmptyInput.
+type ShapeError struct {
+	Op     string  // function that failed
+	Shapes [][]int // shapes of the operands involved
+	Err    error
+	msg    string
+}
+
+func (e *ShapeError) Error() string { return e.msg }
+func (e *ShapeError) Unwrap() error { return e.Err }
+
+// ValueError reports an element whose value the operation cannot accept. Err is one of
+// ErrDivideByZero, ErrDomain, ErrSingular, ErrOverflow or ErrNotPositiveDefinite. Index is the
+// position of the element in the result, or the pivot row for matrices, and is -1 when no single
+// element is to blame.
+type ValueError struct {
+	Op    string
+	Index int
+	Value float64
+	Err   error
+	msg   string
+}
+
+func (e *ValueError) Error() string { return e.msg }
+func (e *ValueError) Unwrap() error { return e.Err }
+
+// PrecisionError reports a precision outside [MinPrecision, MaxPrecision]. It wraps ErrPrecisionRange.
+type PrecisionError struct {
+	Op        string
+	Precision int
+}
+
+func (e *PrecisionError) Error() string {
+	return fmt.Sprintf("precision must be between %d and %d, got %d", MinPrecision, MaxPrecision, e.Precision)
+}
+func (e *PrecisionError) Unwrap() error { return ErrPrecisionRange }
+
+// ArgumentError reports an argument the operation cannot accept, other than the shape or
+// precision of its inputs, such as an axis or percentile out of range. Err is ErrInvalidArgument.
+type ArgumentError struct {
+	Op  string
+	Err error
+	msg string
+}
+
+func (e *ArgumentError) Error() string { return e.msg }
+func (e *ArgumentError) Unwrap() error { return e.Err }
+
+// ConvergenceError reports an iterative algorithm that stopped before meeting its tolerance.
+// Iterations and Residual describe the state it stopped in; Residual is NaN when unknown.
+// It wraps ErrNoConvergence.
+type ConvergenceError struct {
+	Op         string
+	Iterations int
+	Residual   float64
+	msg        string
+}
+
+func (e *ConvergenceError) Error() string { return e.msg }
+func (e *ConvergenceError) Unwrap() error { return ErrNoConvergence }
+
+// shapeError returns a ShapeError wrapping ErrShapeMismatch.
+func shapeError(op string, shapes [][]int, format string, args ...any) error {
+	return &ShapeError{Op: op, Shapes: shapes, Err: ErrShapeMismatch, msg: fmt.Sprintf(format, args...)}
+}
+
+// emptyError returns a ShapeError wrapping ErrEmptyInput.
+func emptyError(op string, shapes [][]int, format string, args ...any) error {
+	return &ShapeError{Op: op, Shapes: shapes, Err: ErrEmptyInput, msg: fmt.Sprintf(format, args...)}
+}
+
+// valueError returns a ValueError wrapping kind.
+func valueError(op string, kind error, index int, value float64, format string, args ...any) error {
+	return &ValueError{Op: op, Index: index, Value: value, Err: kind, msg: fmt.Sprintf(format, args...)}
+}
+
+// argumentError returns an ArgumentError wrapping ErrInvalidArgument.
+func argumentError(op string, format string, args ...any) error {
+	return &ArgumentError{Op: op, Err: ErrInvalidArgument, msg: fmt.Sprintf(format, args...)}
+}
+
+// checkPrecision returns a PrecisionError when precision is outside the supported range.
+func checkPrecision(op string, precision int) error {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return &PrecisionError{Op: op, Precision: precision}
+	}
+	return nil
+}
+
+// squareError returns the error reported for a matrix that is empty or not square.
+func squareError[T element](op string, matrix [][]T) error {
+	if len(matrix) == 0 {
+		return emptyError(op, matrixShapes(matrix), "matrix must be square and non-empty")
+	}
+	return shapeError(op, matrixShapes(matrix), "matrix must be square and non-empty")
+}
+
+// matrixShapes describes a 2D slice for use in errors, taking the number of columns from its first row.
+func matrixShapes[T element](matrix [][]T) [][]int {
+	if len(matrix) == 0 {
+		return [][]int{{0, 0}}
+	}
+	return [][]int{{len(matrix), len(matrix[0])}}
+}
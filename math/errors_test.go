@@ -0,0 +1,87 @@
+package litearray
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	// Test case 1: Mismatched lengths
+	_, err := AddArrays(2, []float64{1, 2, 3}, []float64{1, 2})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+
+	// Test case 2: Division by zero
+	_, err = DivideArrays(2, []float64{1, 2}, []float64{1, 0})
+	if !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v", err)
+	}
+
+	// Test case 3: Singular matrix
+	_, err = InversionMatrix([][]float64{{1, 2}, {2, 4}})
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+
+	// Test case 4: Precision out of range
+	_, err = MeanArrays(11, []float64{1}, []float64{2})
+	if !errors.Is(err, ErrPrecisionRange) {
+		t.Errorf("Expected ErrPrecisionRange, got %v", err)
+	}
+
+	// Test case 5: Empty input
+	_, err = TransposeMatrix(2, nil)
+	if !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+
+	// Test case 6: Domain errors
+	_, err = LogArrays(2, []float64{2, 2}, []float64{4, -1})
+	if !errors.Is(err, ErrDomain) {
+		t.Errorf("Expected ErrDomain, got %v", err)
+	}
+
+	// Test case 7: Invalid arguments
+	_, err = PercentileArrays(2, 150, []float64{1, 2})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestStructuredErrors(t *testing.T) {
+	// Test case 1: ValueError carries the offending index and value
+	_, err := LogArrays(2, []float64{2, 2, 2}, []float64{4, 8, -3})
+	var valueErr *ValueError
+	if !errors.As(err, &valueErr) {
+		t.Fatalf("Expected a ValueError, got %v", err)
+	}
+	if valueErr.Op != "LogArrays" || valueErr.Index != 2 || valueErr.Value != -3 {
+		t.Errorf("Expected LogArrays at index 2 with value -3, got %+v", valueErr)
+	}
+
+	// Test case 2: ShapeError carries the operand shapes
+	a, _ := NewArray([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	b, _ := NewArray([]float64{1, 2}, 2)
+	_, err = a.Add(2, b)
+	var shapeErr *ShapeError
+	if !errors.As(err, &shapeErr) {
+		t.Fatalf("Expected a ShapeError, got %v", err)
+	}
+	if len(shapeErr.Shapes) != 2 || !compareInts(shapeErr.Shapes[0], []int{2, 3}) || !compareInts(shapeErr.Shapes[1], []int{2}) {
+		t.Errorf("Expected shapes [2 3] and [2], got %v", shapeErr.Shapes)
+	}
+
+	// Test case 3: PrecisionError carries the rejected precision
+	_, err = TransposeMatrix(-2, [][]float64{{1}})
+	var precisionErr *PrecisionError
+	if !errors.As(err, &precisionErr) || precisionErr.Precision != -2 {
+		t.Errorf("Expected a PrecisionError for -2, got %v", err)
+	}
+
+	// Test case 4: Messages are unchanged
+	_, err = DeterminantMatrix([][]float64{})
+	if err == nil || err.Error() != "matrix must be square and non-empty" || !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected empty-input error 'matrix must be square and non-empty', got %v", err)
+	}
+}
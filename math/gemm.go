@@ -0,0 +1,50 @@
+package litearray
+
+import "gonum.org/v1/gonum/floats"
+
+// Block sizes for the cache-blocked kernel. A gemmKC×gemmNC block of B (512 KiB) stays in L2
+// while every row of a gemmMC-row block of A sweeps across it, and the row segment of C being
+// updated (4 KiB) stays in L1.
+const (
+	gemmMC = 64
+	gemmKC = 128
+	gemmNC = 512
+
+	// Below this many multiply-adds blocking costs more than it saves
+	gemmThreshold = 32 * 32 * 32
+)
+
+// gemm adds A·B to C, where A is m×k, B is k×n and C is m×n, all row-major and contiguous. Each
+// row of C is updated with scaled rows of B, which Gonum computes with SIMD instructions where
+// available. Large products are split into cache-sized blocks, and blocks of rows are shared
+// between the call's workers, so every worker writes to its own rows of C.
+func (c *config) gemm(m, n, k int, a, b, dst []float64) {
+	if m*n*k < gemmThreshold {
+		gemmBlock(n, k, a, b, dst, 0, m, 0, n, 0, k)
+		return
+	}
+
+	blocks := (m + gemmMC - 1) / gemmMC
+	c.parallelChunks(blocks, 1, func(lo, hi int) {
+		first, last := lo*gemmMC, min(hi*gemmMC, m)
+		for jc := 0; jc < n; jc += gemmNC {
+			for pc := 0; pc < k; pc += gemmKC {
+				for ic := first; ic < last; ic += gemmMC {
+					gemmBlock(n, k, a, b, dst, ic, min(ic+gemmMC, last), jc, min(jc+gemmNC, n), pc, min(pc+gemmKC, k))
+				}
+			}
+		}
+	})
+}
+
+// gemmBlock adds A[i0:i1, p0:p1]·B[p0:p1, j0:j1] to C[i0:i1, j0:j1], where A has k columns and
+// B and C have n.
+func gemmBlock(n, k int, a, b, dst []float64, i0, i1, j0, j1, p0, p1 int) {
+	for i := i0; i < i1; i++ {
+		row := dst[i*n+j0 : i*n+j1]
+		for p, factor := range a[i*k+p0 : i*k+p1] {
+			p += p0
+			floats.AddScaled(row, factor, b[p*n+j0:p*n+j1])
+		}
+	}
+}
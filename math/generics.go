@@ -0,0 +1,309 @@
+package litearray
+
+import "math"
+
+// Integer is the set of integer element types accepted by the generic functions.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is the set of floating-point element types accepted by the generic functions.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the set of element types accepted by the generic functions.
+//
+// The generic functions follow these promotion rules:
+//   - AddOf, SubtractOf, MultiplyOf, SumOf, MinOf, MaxOf and PeakToPeakOf keep the element type.
+//     Integer results are computed exactly and an overflow is reported as ErrOverflow; they are
+//     never rounded. Float results are computed in float64, rounded, then converted back.
+//   - DivideOf, MeanOf, VarianceOf and StandardDeviationOf always return float64, like true
+//     division and the mean of integers in NumPy. Integers beyond 2^53 lose precision.
+//
+// Functions keeping the element type do not accept WithOut, whose slice is []float64.
+type Number interface {
+	Integer | Float
+}
+
+// element is the set of element types accepted by the shape checks shared between the real and
+// complex functions.
+type element interface {
+	Number | ~complex64 | ~complex128
+}
+
+// AddOf adds arrays of any Number type element-wise, broadcasting arrays that hold a single element.
+func AddOf[T Number](arrays [][]T, opts ...Option) ([]T, error) {
+	const op = "AddOf"
+	if !isInteger[T]() {
+		return viaFloat64(op, arrays, opts, add)
+	}
+	if err := checkIntegerOptions(op, opts); err != nil {
+		return nil, err
+	}
+
+	length, err := checkOperands(op, arrays, false, "at least two arrays are required to perform addition")
+	if err != nil {
+		return nil, err
+	}
+	return foldIntegers(op, arrays, length, addChecked[T])
+}
+
+// SubtractOf subtracts the remaining arrays from the first element-wise for any Number type.
+func SubtractOf[T Number](arrays [][]T, opts ...Option) ([]T, error) {
+	const op = "SubtractOf"
+	if !isInteger[T]() {
+		return viaFloat64(op, arrays, opts, subtract)
+	}
+	if err := checkIntegerOptions(op, opts); err != nil {
+		return nil, err
+	}
+
+	length, err := checkOperands(op, arrays, true, "at least two arrays are required to perform subtraction")
+	if err != nil {
+		return nil, err
+	}
+	return foldIntegers(op, arrays, length, subtractChecked[T])
+}
+
+// MultiplyOf multiplies arrays of any Number type element-wise.
+func MultiplyOf[T Number](arrays [][]T, opts ...Option) ([]T, error) {
+	const op = "MultiplyOf"
+	if !isInteger[T]() {
+		return viaFloat64(op, arrays, opts, multiply)
+	}
+	if err := checkIntegerOptions(op, opts); err != nil {
+		return nil, err
+	}
+
+	length, err := checkOperands(op, arrays, true, "at least two arrays are required to perform multiplication")
+	if err != nil {
+		return nil, err
+	}
+	return foldIntegers(op, arrays, length, multiplyChecked[T])
+}
+
+// DivideOf divides the first array by the remaining arrays element-wise, returning float64 results
+// for every element type.
+func DivideOf[T Number](arrays [][]T, opts ...Option) ([]float64, error) {
+	return divide("DivideOf", widen(arrays), opts)
+}
+
+// SumOf sums the elements of values. The sum of no values is zero.
+func SumOf[T Number](values []T, opts ...Option) (T, error) {
+	const op = "SumOf"
+	if !isInteger[T]() {
+		c, err := newConfig(op, opts)
+		if err != nil {
+			return 0, err
+		}
+		floats := widen([][]T{values})[0]
+		if err := c.checkNaN(op, floats); err != nil {
+			return 0, err
+		}
+
+		sum := []float64{0}
+		for _, v := range floats {
+			if !(c.omitNaN() && math.IsNaN(v)) {
+				sum[0] += v
+			}
+		}
+		c.round(op, shapesOf(floats), sum)
+		return T(sum[0]), nil
+	}
+	if err := checkIntegerOptions(op, opts); err != nil {
+		return 0, err
+	}
+
+	var sum T
+	for i, v := range values {
+		next, ok := addChecked(sum, v)
+		if !ok {
+			return 0, valueError(op, ErrOverflow, i, float64(v), "integer overflow at index %d", i)
+		}
+		sum = next
+	}
+	return sum, nil
+}
+
+// MeanOf calculates the mean of arrays of equal length element-wise, returning float64 results
+// for every element type.
+func MeanOf[T Number](arrays [][]T, opts ...Option) ([]float64, error) {
+	return mean("MeanOf", widen(arrays), opts)
+}
+
+// VarianceOf calculates the variance of arrays of equal length element-wise like Variance,
+// returning float64 results for every element type.
+func VarianceOf[T Number](arrays [][]T, opts ...Option) ([]float64, error) {
+	return variance("VarianceOf", widen(arrays), opts)
+}
+
+// StandardDeviationOf calculates the standard deviation of arrays of equal length element-wise like
+// StandardDeviation, returning float64 results for every element type.
+func StandardDeviationOf[T Number](arrays [][]T, opts ...Option) ([]float64, error) {
+	return standardDeviation("StandardDeviationOf", widen(arrays), opts)
+}
+
+// MinOf finds the minimum value at each position across arrays of equal length.
+func MinOf[T Number](arrays [][]T, opts ...Option) ([]T, error) {
+	const op = "MinOf"
+	if !isInteger[T]() {
+		return viaFloat64(op, arrays, opts, minimum)
+	}
+	if err := checkIntegerStatistics(op, arrays, opts); err != nil {
+		return nil, err
+	}
+	return foldIntegers(op, arrays, len(arrays[0]), func(acc, v T) (T, bool) { return min(acc, v), true })
+}
+
+// MaxOf finds the maximum value at each position across arrays of equal length.
+func MaxOf[T Number](arrays [][]T, opts ...Option) ([]T, error) {
+	const op = "MaxOf"
+	if !isInteger[T]() {
+		return viaFloat64(op, arrays, opts, maximum)
+	}
+	if err := checkIntegerStatistics(op, arrays, opts); err != nil {
+		return nil, err
+	}
+	return foldIntegers(op, arrays, len(arrays[0]), func(acc, v T) (T, bool) { return max(acc, v), true })
+}
+
+// PeakToPeakOf calculates the range (max - min) at each position across arrays of equal length.
+// For signed integers the range itself can overflow, as int8 values -128 and 127 do.
+func PeakToPeakOf[T Number](arrays [][]T, opts ...Option) ([]T, error) {
+	const op = "PeakToPeakOf"
+	if !isInteger[T]() {
+		return viaFloat64(op, arrays, opts, peakToPeak)
+	}
+	if err := checkIntegerStatistics(op, arrays, opts); err != nil {
+		return nil, err
+	}
+
+	result := make([]T, len(arrays[0]))
+	for i := range result {
+		lo, hi := arrays[0][i], arrays[0][i]
+		for _, array := range arrays[1:] {
+			lo, hi = min(lo, array[i]), max(hi, array[i])
+		}
+		spread, ok := subtractChecked(hi, lo)
+		if !ok {
+			return nil, valueError(op, ErrOverflow, i, float64(hi), "integer overflow at index %d", i)
+		}
+		result[i] = spread
+	}
+	return result, nil
+}
+
+// isInteger reports whether T is an integer type.
+func isInteger[T Number]() bool {
+	half := 0.5
+	return T(half) == 0
+}
+
+// widen converts arrays to float64, sharing them when they already are.
+func widen[T Number](arrays [][]T) [][]float64 {
+	if floats, ok := any(arrays).([][]float64); ok {
+		return floats
+	}
+	floats := make([][]float64, len(arrays))
+	for i, array := range arrays {
+		if array == nil {
+			continue
+		}
+		floats[i] = make([]float64, len(array))
+		for j, v := range array {
+			floats[i][j] = float64(v)
+		}
+	}
+	return floats
+}
+
+// viaFloat64 runs the float64 implementation fn on arrays of a float type and converts the
+// results back to that type.
+func viaFloat64[T Number](op string, arrays [][]T, opts []Option, fn func(string, [][]float64, []Option) ([]float64, error)) ([]T, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.out != nil {
+		return nil, argumentError(op, "WithOut is only supported by functions returning float64")
+	}
+
+	floats, err := fn(op, widen(arrays), opts)
+	if err != nil {
+		return nil, err
+	}
+	if result, ok := any(floats).([]T); ok {
+		return result, nil
+	}
+	result := make([]T, len(floats))
+	for i, v := range floats {
+		result[i] = T(v)
+	}
+	return result, nil
+}
+
+// checkIntegerOptions validates opts for a function computing exact integer results.
+func checkIntegerOptions(op string, opts []Option) error {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return err
+	}
+	if c.out != nil {
+		return argumentError(op, "WithOut is only supported by functions returning float64")
+	}
+	return nil
+}
+
+// checkIntegerStatistics validates opts and the arrays of a statistic computed across integer arrays.
+func checkIntegerStatistics[T Number](op string, arrays [][]T, opts []Option) error {
+	if err := checkIntegerOptions(op, opts); err != nil {
+		return err
+	}
+
+	// Ensure at least two arrays are provided
+	if len(arrays) < 2 {
+		return argumentError(op, "at least two arrays are required to perform addition")
+	}
+	return sameLength(op, arrays)
+}
+
+// foldIntegers combines the arrays element-wise with step, repeating single-element arrays, and
+// reports the first position where step overflows.
+func foldIntegers[T Number](op string, arrays [][]T, length int, step func(acc, v T) (T, bool)) ([]T, error) {
+	result := make([]T, length)
+	for i := range result {
+		acc := arrays[0][i%len(arrays[0])]
+		for _, array := range arrays[1:] {
+			next, ok := step(acc, array[i%len(array)])
+			if !ok {
+				return nil, valueError(op, ErrOverflow, i, float64(acc), "integer overflow at index %d", i)
+			}
+			acc = next
+		}
+		result[i] = acc
+	}
+	return result, nil
+}
+
+// addChecked returns a + b and whether the integer addition did not overflow.
+func addChecked[T Number](a, b T) (T, bool) {
+	sum := a + b
+	return sum, !(b > 0 && sum < a || b < 0 && sum > a)
+}
+
+// subtractChecked returns a - b and whether the integer subtraction did not overflow.
+func subtractChecked[T Number](a, b T) (T, bool) {
+	difference := a - b
+	return difference, !(b > 0 && difference > a || b < 0 && difference < a)
+}
+
+// multiplyChecked returns a * b and whether the integer multiplication did not overflow. The
+// product of two negative numbers must be positive, which catches MinInt * -1.
+func multiplyChecked[T Number](a, b T) (T, bool) {
+	product := a * b
+	if a == 0 {
+		return product, true
+	}
+	return product, product/a == b && !(a < 0 && b < 0 && product < 0)
+}
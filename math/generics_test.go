@@ -0,0 +1,109 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAddOf(t *testing.T) {
+	// Test case 1: Integer addition keeps the element type
+	ints, err := AddOf([][]int16{{1, 2, 3}, {10, 20, 30}})
+	if err != nil || len(ints) != 3 || ints[0] != 11 || ints[2] != 33 {
+		t.Errorf("Expected [11 22 33], got %v (err %v)", ints, err)
+	}
+
+	// Test case 2: Overflow is reported with its position
+	_, err = AddOf([][]int8{{1, 100}, {1, 100}})
+	var valueErr *ValueError
+	if !errors.Is(err, ErrOverflow) || !errors.As(err, &valueErr) || valueErr.Index != 1 {
+		t.Errorf("Expected ErrOverflow at index 1, got %v", err)
+	}
+
+	// Test case 3: Floats are rounded and converted back
+	floats, err := AddOf([][]float32{{1.125, 2}, {1, 1}}, WithPrecision(2))
+	if err != nil || floats[0] != float32(2.13) || floats[1] != 3 {
+		t.Errorf("Expected [2.13 3], got %v (err %v)", floats, err)
+	}
+
+	// Test case 4: Validation matches the float64 functions
+	_, err = AddOf([][]int32{{1, 2, 3}, {1, 2}})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	_, err = AddOf([][]int32{{1}, {2}}, WithOut(make([]float64, 1)))
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument for WithOut, got %v", err)
+	}
+}
+
+func TestCheckedArithmeticOf(t *testing.T) {
+	// Test case 1: Subtraction below the minimum
+	_, err := SubtractOf([][]int8{{-100}, {100}})
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+
+	// Test case 2: Unsigned subtraction below zero
+	_, err = SubtractOf([][]uint{{1}, {2}})
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow for unsigned, got %v", err)
+	}
+
+	// Test case 3: The most negative value times -1
+	_, err = MultiplyOf([][]int64{{math.MinInt64}, {-1}})
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow for MinInt64 * -1, got %v", err)
+	}
+
+	// Test case 4: Products that fit are exact
+	product, err := MultiplyOf([][]int64{{math.MaxInt64 / 2}, {2}})
+	if err != nil || product[0] != math.MaxInt64-1 {
+		t.Errorf("Expected %d, got %v (err %v)", int64(math.MaxInt64-1), product, err)
+	}
+
+	// Test case 5: Sums of a single slice
+	sum, err := SumOf([]uint8{100, 100, 55})
+	if err != nil || sum != 255 {
+		t.Errorf("Expected 255, got %v (err %v)", sum, err)
+	}
+	_, err = SumOf([]uint8{100, 100, 56})
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestPromotionOf(t *testing.T) {
+	// Test case 1: The mean of integers is float64
+	mean, err := MeanOf([][]int{{1, 2}, {2, 2}})
+	if err != nil || !compareSlices(mean, []float64{1.5, 2}, 0) {
+		t.Errorf("Expected [1.5 2], got %v (err %v)", mean, err)
+	}
+
+	// Test case 2: Integer division is true division
+	quotient, err := DivideOf([][]int{{7}, {2}})
+	if err != nil || !compareSlices(quotient, []float64{3.5}, 0) {
+		t.Errorf("Expected [3.5], got %v (err %v)", quotient, err)
+	}
+
+	// Test case 3: Variance and standard deviation of integers
+	variance, _ := VarianceOf([][]int32{{1}, {3}})
+	stdDev, _ := StandardDeviationOf([][]int32{{1}, {3}})
+	if !compareSlices(variance, []float64{1}, 0) || !compareSlices(stdDev, []float64{1}, 0) {
+		t.Errorf("Expected variance and standard deviation of 1, got %v and %v", variance, stdDev)
+	}
+
+	// Test case 4: Min, max and range keep the element type
+	lo, _ := MinOf([][]uint16{{3, 9}, {5, 1}})
+	hi, _ := MaxOf([][]uint16{{3, 9}, {5, 1}})
+	spread, _ := PeakToPeakOf([][]uint16{{3, 9}, {5, 1}})
+	if lo[0] != 3 || lo[1] != 1 || hi[0] != 5 || hi[1] != 9 || spread[0] != 2 || spread[1] != 8 {
+		t.Errorf("Expected min [3 1], max [5 9], range [2 8], got %v, %v, %v", lo, hi, spread)
+	}
+
+	// Test case 5: The range of signed integers can overflow
+	_, err = PeakToPeakOf([][]int8{{-128}, {127}})
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+}
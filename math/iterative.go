@@ -0,0 +1,509 @@
+package litearray
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// defaultIterativeTolerance is the relative residual ‖b − A·x‖/‖b‖ the iterative solvers stop
+// at unless WithTolerance says otherwise.
+const defaultIterativeTolerance = 1e-10
+
+// defaultRestart is the number of GMRES iterations between restarts unless WithRestart says
+// otherwise.
+const defaultRestart = 30
+
+// IterativeResult describes the solution of A·x = b found by an iterative solver.
+type IterativeResult struct {
+	// Solution is the last iterate. When the solver returns a ConvergenceError it is the best
+	// approximation reached before stopping.
+	Solution []float64
+	// Iterations is the number of iterations run, each costing one or two products with A.
+	Iterations int
+	// Residual is the relative residual ‖b − A·x‖/‖b‖ of Solution, recomputed from A rather than
+	// taken from the solver's running estimate.
+	Residual float64
+}
+
+// Preconditioner approximates the inverse of a matrix M ≈ A, turning A·x = b into a better
+// conditioned system that the iterative solvers solve in fewer iterations.
+type Preconditioner interface {
+	// Solve writes the solution of M·z = r to z. z and r have the size of the system and do not
+	// overlap.
+	Solve(z, r []float64)
+}
+
+// jacobi preconditions with the diagonal of A.
+type jacobi struct {
+	inverse []float64
+}
+
+// ilu preconditions with the incomplete LU factorization of A with no fill-in: L and U share the
+// sparsity pattern of A, with the unit diagonal of L left implicit.
+type ilu struct {
+	factors  *CSR
+	diagonal []int // diagonal[i] is the position of entry (i, i) in factors
+}
+
+// NewJacobi returns the Jacobi preconditioner M = diag(A), which is cheap to build and apply and
+// works well for diagonally dominant matrices. It returns an error wrapping ErrSingular if a
+// diagonal entry is zero.
+func NewJacobi(a Sparse) (Preconditioner, error) {
+	const op = "NewJacobi"
+	m, err := checkSystem(op, a)
+	if err != nil {
+		return nil, err
+	}
+	inverse := make([]float64, m.major)
+	for i := range inverse {
+		d := m.at(i, i)
+		if d == 0 {
+			return nil, valueError(op, ErrSingular, i, d, "diagonal entry %d is zero", i)
+		}
+		inverse[i] = 1 / d
+	}
+	return &jacobi{inverse: inverse}, nil
+}
+
+func (p *jacobi) Solve(z, r []float64) {
+	for i, v := range r {
+		z[i] = v * p.inverse[i]
+	}
+}
+
+func (p *jacobi) size() int { return len(p.inverse) }
+
+// NewILU returns the ILU(0) preconditioner M = L·U, the LU factorization of A with every entry
+// outside the sparsity pattern of A dropped. It costs about as much as one product with A to apply
+// and usually cuts the iteration count far more than Jacobi. It returns an error wrapping
+// ErrSingular if a diagonal entry is missing or a pivot becomes zero.
+func NewILU(a Sparse) (Preconditioner, error) {
+	const op = "NewILU"
+	m, err := checkSystem(op, a)
+	if err != nil {
+		return nil, err
+	}
+	n := m.major
+	values := append([]float64(nil), m.values...)
+	f := &CSR{compressed{n, n, m.indptr, m.indices, values}}
+
+	// Locate the diagonal of every row
+	diagonal := make([]int, n)
+	for i := range diagonal {
+		diagonal[i] = -1
+		for k := f.indptr[i]; k < f.indptr[i+1] && f.indices[k] <= i; k++ {
+			if f.indices[k] == i {
+				diagonal[i] = k
+			}
+		}
+		if diagonal[i] < 0 {
+			return nil, valueError(op, ErrSingular, i, 0, "diagonal entry %d is not stored", i)
+		}
+	}
+
+	// Eliminate row by row, updating only the entries already in the pattern
+	for i := 0; i < n; i++ {
+		for k := f.indptr[i]; k < diagonal[i]; k++ {
+			p := f.indices[k]
+			pivot := values[diagonal[p]]
+			if pivot == 0 {
+				return nil, valueError(op, ErrSingular, p, pivot, "pivot %d is zero", p)
+			}
+			values[k] /= pivot
+			l := values[k]
+
+			// Subtract l times the upper part of row p, merging the two sorted rows
+			q, r := diagonal[p]+1, k+1
+			for q < f.indptr[p+1] && r < f.indptr[i+1] {
+				switch {
+				case f.indices[q] < f.indices[r]:
+					q++
+				case f.indices[q] > f.indices[r]:
+					r++
+				default:
+					values[r] -= l * values[q]
+					q++
+					r++
+				}
+			}
+		}
+		if values[diagonal[i]] == 0 {
+			return nil, valueError(op, ErrSingular, i, 0, "pivot %d is zero", i)
+		}
+	}
+	return &ilu{factors: f, diagonal: diagonal}, nil
+}
+
+func (p *ilu) Solve(z, r []float64) {
+	f := p.factors
+
+	// Forward substitution with the unit lower triangle
+	for i := 0; i < f.major; i++ {
+		sum := r[i]
+		for k := f.indptr[i]; k < p.diagonal[i]; k++ {
+			sum -= f.values[k] * z[f.indices[k]]
+		}
+		z[i] = sum
+	}
+
+	// Back substitution with the upper triangle
+	for i := f.major - 1; i >= 0; i-- {
+		sum := z[i]
+		for k := p.diagonal[i] + 1; k < f.indptr[i+1]; k++ {
+			sum -= f.values[k] * z[f.indices[k]]
+		}
+		z[i] = sum / f.values[p.diagonal[i]]
+	}
+}
+
+func (p *ilu) size() int { return p.factors.major }
+
+// ConjugateGradient solves A·x = b for a symmetric positive definite sparse A by the (optionally
+// preconditioned) conjugate gradient method. The preconditioner must be symmetric positive
+// definite as well; Jacobi always is for such A, ILU(0) is for most.
+//
+// The solver stops when the relative residual ‖b − A·x‖/‖b‖ drops to the tolerance, 1e-10 unless
+// set with WithTolerance, or after WithMaxIterations iterations, 10n by default. Stopping early
+// returns the last iterate together with a ConvergenceError. A direction with pᵀ·A·p ≤ 0 shows
+// that A is not positive definite and gives a ValueError wrapping ErrNotPositiveDefinite.
+// WithInitialGuess sets the starting point, WithOut the destination of the solution and
+// WithParallelism the goroutines used for products with A.
+func ConjugateGradient(a Sparse, b []float64, opts ...Option) (*IterativeResult, error) {
+	s, err := newIterative("ConjugateGradient", a, b, opts)
+	if err != nil || s.done() {
+		return s.finish(err)
+	}
+	n := s.n
+	r, z, p, ap := s.residual(), make([]float64, n), make([]float64, n), make([]float64, n)
+	if s.converged(r) {
+		return s.finish(nil)
+	}
+	s.precondition(z, r)
+	copy(p, z)
+	rz := floats.Dot(r, z)
+
+	for s.iterations < s.maxIterations {
+		s.iterations++
+		s.mulVec(ap, p)
+		curvature := floats.Dot(p, ap)
+		if curvature <= 0 {
+			return s.finish(valueError(s.op, ErrNotPositiveDefinite, -1, curvature, "matrix is not positive definite: found a direction with pᵀ·A·p = %g", curvature))
+		}
+		alpha := rz / curvature
+		floats.AddScaled(s.x, alpha, p)
+		floats.AddScaled(r, -alpha, ap)
+		if s.converged(r) {
+			return s.finish(nil)
+		}
+
+		s.precondition(z, r)
+		next := floats.Dot(r, z)
+		beta := next / rz
+		rz = next
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+	return s.finish(s.exhausted())
+}
+
+// BiCGSTAB solves A·x = b for a general square sparse A by the (optionally right-preconditioned)
+// biconjugate gradient stabilized method. It needs two products with A per iteration but little
+// memory, and suits nonsymmetric systems whose eigenvalues avoid the origin.
+//
+// Tolerance, iteration limit and the other options are as for ConjugateGradient. A breakdown of
+// the recurrence, which can happen for unlucky systems, stops the solver with a ConvergenceError.
+func BiCGSTAB(a Sparse, b []float64, opts ...Option) (*IterativeResult, error) {
+	s, err := newIterative("BiCGSTAB", a, b, opts)
+	if err != nil || s.done() {
+		return s.finish(err)
+	}
+	n := s.n
+	r := s.residual()
+	if s.converged(r) {
+		return s.finish(nil)
+	}
+	shadow := append([]float64(nil), r...)
+	p, v := make([]float64, n), make([]float64, n)
+	pHat, sHat, t := make([]float64, n), make([]float64, n), make([]float64, n)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+
+	for s.iterations < s.maxIterations {
+		s.iterations++
+		next := floats.Dot(shadow, r)
+		if next == 0 || omega == 0 {
+			return s.finish(s.breakdown())
+		}
+		beta := next / rho * alpha / omega
+		rho = next
+		for i := range p {
+			p[i] = r[i] + beta*(p[i]-omega*v[i])
+		}
+		s.precondition(pHat, p)
+		s.mulVec(v, pHat)
+		denominator := floats.Dot(shadow, v)
+		if denominator == 0 {
+			return s.finish(s.breakdown())
+		}
+		alpha = rho / denominator
+
+		// r now holds the intermediate residual s = r − α·v
+		floats.AddScaled(r, -alpha, v)
+		floats.AddScaled(s.x, alpha, pHat)
+		if s.converged(r) {
+			return s.finish(nil)
+		}
+
+		s.precondition(sHat, r)
+		s.mulVec(t, sHat)
+		tt := floats.Dot(t, t)
+		if tt == 0 {
+			return s.finish(s.breakdown())
+		}
+		omega = floats.Dot(t, r) / tt
+		floats.AddScaled(s.x, omega, sHat)
+		floats.AddScaled(r, -omega, t)
+		if s.converged(r) {
+			return s.finish(nil)
+		}
+	}
+	return s.finish(s.exhausted())
+}
+
+// GMRES solves A·x = b for a general square sparse A by the restarted generalized minimal
+// residual method with right preconditioning. Each cycle builds an orthonormal Krylov basis of up
+// to WithRestart vectors, 30 by default, and picks the iterate with the smallest residual in it,
+// so the residual never grows. Longer cycles converge in fewer iterations at the cost of memory
+// and orthogonalization work that grow with their length.
+//
+// Tolerance, iteration limit and the other options are as for ConjugateGradient; each iteration
+// adds one basis vector.
+func GMRES(a Sparse, b []float64, opts ...Option) (*IterativeResult, error) {
+	s, err := newIterative("GMRES", a, b, opts)
+	if err != nil || s.done() {
+		return s.finish(err)
+	}
+	n := s.n
+	m := min(s.c.restart, n)
+	if m == 0 {
+		m = min(defaultRestart, n)
+	}
+
+	// Krylov basis V, preconditioned basis Z, Hessenberg matrix H reduced by Givens rotations
+	// (cs, sn) and the rotated right-hand side g
+	basis, preconditioned := rowsOf(make([]float64, (m+1)*n), m+1, n), rowsOf(make([]float64, m*n), m, n)
+	h := newMatrix(m+1, m)
+	cs, sn, g, y := make([]float64, m), make([]float64, m), make([]float64, m+1), make([]float64, m)
+
+	for s.iterations < s.maxIterations {
+		r := s.residual()
+		beta := norm2(r)
+		if beta <= s.tolerance*s.bNorm {
+			return s.finish(nil)
+		}
+		floats.ScaleTo(basis[0], 1/beta, r)
+		clear(g)
+		g[0] = beta
+
+		k, stalled := 0, false
+		for k < m && s.iterations < s.maxIterations {
+			s.iterations++
+			w := basis[k+1]
+			s.precondition(preconditioned[k], basis[k])
+			s.mulVec(w, preconditioned[k])
+
+			// Modified Gram–Schmidt against the basis so far
+			for i := 0; i <= k; i++ {
+				h[i][k] = floats.Dot(w, basis[i])
+				floats.AddScaled(w, -h[i][k], basis[i])
+			}
+			h[k+1][k] = norm2(w)
+			if h[k+1][k] != 0 {
+				floats.Scale(1/h[k+1][k], w)
+			}
+
+			// Apply the previous rotations to the new column, then zero its subdiagonal
+			for i := 0; i < k; i++ {
+				h[i][k], h[i+1][k] = cs[i]*h[i][k]+sn[i]*h[i+1][k], -sn[i]*h[i][k]+cs[i]*h[i+1][k]
+			}
+			radius := math.Hypot(h[k][k], h[k+1][k])
+			if radius == 0 {
+				stalled = true
+				break
+			}
+			cs[k], sn[k] = h[k][k]/radius, h[k+1][k]/radius
+			h[k][k], h[k+1][k] = radius, 0
+			g[k], g[k+1] = cs[k]*g[k], -sn[k]*g[k]
+			k++
+			if math.Abs(g[k]) <= s.tolerance*s.bNorm {
+				break
+			}
+		}
+
+		// Solve the triangular least-squares problem and update x with the preconditioned basis
+		for i := k - 1; i >= 0; i-- {
+			sum := g[i]
+			for j := i + 1; j < k; j++ {
+				sum -= h[i][j] * y[j]
+			}
+			y[i] = sum / h[i][i]
+		}
+		for i := 0; i < k; i++ {
+			floats.AddScaled(s.x, y[i], preconditioned[i])
+		}
+		if math.Abs(g[k]) <= s.tolerance*s.bNorm {
+			return s.finish(nil)
+		}
+		if stalled {
+			return s.finish(s.breakdown())
+		}
+	}
+	return s.finish(s.exhausted())
+}
+
+// iterative holds the state shared by the iterative solvers.
+type iterative struct {
+	op            string
+	c             *config
+	a             *CSR
+	b             []float64
+	x             []float64
+	n             int
+	bNorm         float64
+	tolerance     float64
+	maxIterations int
+	iterations    int
+}
+
+// newIterative validates the system and options and sets x to the initial guess.
+func newIterative(op string, a Sparse, b []float64, opts []Option) (*iterative, error) {
+	s := &iterative{op: op}
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return s, err
+	}
+	m, err := checkSystem(op, a)
+	if err != nil {
+		return s, err
+	}
+	n := m.major
+	if len(b) != n {
+		return s, shapeError(op, [][]int{{n, n}, {len(b)}}, "right-hand side has length %d, expected %d", len(b), n)
+	}
+	if c.initialGuess != nil && len(c.initialGuess) != n {
+		return s, shapeError(op, [][]int{{n, n}, {len(c.initialGuess)}}, "initial guess has length %d, expected %d", len(c.initialGuess), n)
+	}
+	if sized, ok := c.preconditioner.(interface{ size() int }); ok && sized.size() != n {
+		return s, shapeError(op, [][]int{{n, n}, {sized.size(), sized.size()}}, "preconditioner has size %d, expected %d", sized.size(), n)
+	}
+	if err := c.checkNaN(op, m.values, b, c.initialGuess); err != nil {
+		return s, err
+	}
+
+	x, err := c.result(op, n)
+	if err != nil {
+		return s, err
+	}
+	if c.initialGuess != nil {
+		copy(x, c.initialGuess)
+	} else {
+		clear(x)
+	}
+
+	s.c, s.a, s.b, s.x, s.n = c, m, b, x, n
+	s.bNorm = norm2(b)
+	s.tolerance = c.toleranceOr(defaultIterativeTolerance)
+	s.maxIterations = c.maxIterations
+	if s.maxIterations == 0 {
+		s.maxIterations = 10 * n
+	}
+	return s, nil
+}
+
+// done reports whether b is zero, in which case the solution is zero as well.
+func (s *iterative) done() bool {
+	if s.bNorm == 0 {
+		clear(s.x)
+		return true
+	}
+	return false
+}
+
+// mulVec writes A·x to y.
+func (s *iterative) mulVec(y, x []float64) {
+	s.c.parallelChunks(s.n, max(1, minChunk*s.n/max(len(s.a.values), 1)), func(lo, hi int) {
+		s.a.mulVec(y, x, lo, hi)
+	})
+}
+
+// residual returns b − A·x for the current iterate.
+func (s *iterative) residual() []float64 {
+	r := make([]float64, s.n)
+	s.mulVec(r, s.x)
+	floats.SubTo(r, s.b, r)
+	return r
+}
+
+// precondition writes M⁻¹·r to z, or copies r without a preconditioner.
+func (s *iterative) precondition(z, r []float64) {
+	if s.c.preconditioner == nil {
+		copy(z, r)
+		return
+	}
+	s.c.preconditioner.Solve(z, r)
+}
+
+// converged reports whether the running residual r meets the tolerance.
+func (s *iterative) converged(r []float64) bool {
+	return norm2(r) <= s.tolerance*s.bNorm
+}
+
+// exhausted returns the error for running out of iterations.
+func (s *iterative) exhausted() error {
+	return &ConvergenceError{Op: s.op, Iterations: s.iterations, Residual: s.trueResidual(),
+		msg: "did not reach the tolerance within the iteration limit"}
+}
+
+// breakdown returns the error for a recurrence that cannot continue.
+func (s *iterative) breakdown() error {
+	return &ConvergenceError{Op: s.op, Iterations: s.iterations, Residual: s.trueResidual(),
+		msg: "iteration broke down before reaching the tolerance"}
+}
+
+// trueResidual returns ‖b − A·x‖/‖b‖ for the current iterate.
+func (s *iterative) trueResidual() float64 {
+	if s.bNorm == 0 {
+		return 0
+	}
+	return norm2(s.residual()) / s.bNorm
+}
+
+// finish rounds the solution and builds the result. Validation errors give no result; any other
+// error is returned alongside the last iterate.
+func (s *iterative) finish(err error) (*IterativeResult, error) {
+	if s.c == nil {
+		return nil, err
+	}
+	result := &IterativeResult{Solution: s.x, Iterations: s.iterations, Residual: s.trueResidual()}
+
+	// Apply rounding if precision is non-negative
+	s.c.round(s.op, [][]int{{s.n, s.n}, {s.n}}, s.x)
+
+	return result, err
+}
+
+// checkSystem checks that a is square and returns it in CSR format.
+func checkSystem(op string, a Sparse) (*CSR, error) {
+	rows, cols := a.Dims()
+	if rows != cols {
+		return nil, shapeError(op, [][]int{{rows, cols}}, "matrix must be square, got %dx%d", rows, cols)
+	}
+	return a.ToCSR(), nil
+}
+
+// norm2 returns the Euclidean norm of x. Unlike floats.Norm it does not guard against overflow,
+// which the solvers can afford in exchange for a single pass.
+func norm2(x []float64) float64 {
+	return math.Sqrt(floats.Dot(x, x))
+}
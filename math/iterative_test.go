@@ -0,0 +1,125 @@
+package litearray
+
+import (
+	"errors"
+	"testing"
+)
+
+// tridiagonal returns the n×n sparse matrix with lower, diagonal and upper on its three central
+// diagonals.
+func tridiagonal(n int, lower, diagonal, upper float64) *CSR {
+	coo, _ := NewCOO(n, n, nil, nil, nil)
+	for i := 0; i < n; i++ {
+		coo.Append(i, i, diagonal)
+		if i > 0 {
+			coo.Append(i, i-1, lower)
+		}
+		if i < n-1 {
+			coo.Append(i, i+1, upper)
+		}
+	}
+	return coo.ToCSR()
+}
+
+// checkSolution checks that x solves A·x = b to within tolerance.
+func checkSolution(t *testing.T, a Sparse, x, b []float64, tolerance float64) {
+	t.Helper()
+	y, _ := a.MatVec(x)
+	if !compareSlices(y, b, tolerance) {
+		t.Errorf("Expected A·x = b, residual from %v", y[:3])
+	}
+}
+
+func TestConjugateGradient(t *testing.T) {
+	laplacian := tridiagonal(200, -1, 2, -1)
+	b := make([]float64, 200)
+	for i := range b {
+		b[i] = float64(i%7) - 3
+	}
+
+	// Test case 1: Plain and preconditioned solves of a 1D Laplacian
+	plain, err := ConjugateGradient(laplacian, b)
+	if err != nil || plain.Residual > 1e-10 {
+		t.Fatalf("Expected convergence, got %+v (err %v)", plain.Iterations, err)
+	}
+	checkSolution(t, laplacian, plain.Solution, b, 1e-6)
+	ilu, _ := NewILU(laplacian)
+	preconditioned, err := ConjugateGradient(laplacian, b, WithPreconditioner(ilu))
+	if err != nil || preconditioned.Iterations > 2 {
+		t.Errorf("Expected ILU(0), exact for a tridiagonal matrix, to converge at once, got %d iterations (err %v)", preconditioned.Iterations, err)
+	}
+
+	// Test case 2: Starting from the solution takes no iterations
+	result, err := ConjugateGradient(laplacian, b, WithInitialGuess(plain.Solution), WithTolerance(1e-6))
+	if err != nil || result.Iterations != 0 {
+		t.Errorf("Expected no iterations, got %d (err %v)", result.Iterations, err)
+	}
+
+	// Test case 3: Running out of iterations returns the partial solution
+	result, err = ConjugateGradient(laplacian, b, WithMaxIterations(3))
+	var convergence *ConvergenceError
+	if !errors.As(err, &convergence) || convergence.Iterations != 3 || result == nil || result.Residual != convergence.Residual {
+		t.Errorf("Expected a ConvergenceError after 3 iterations, got %+v (err %v)", result, err)
+	}
+
+	// Test case 4: Indefinite matrices and invalid input
+	if _, err := ConjugateGradient(tridiagonal(5, 1, -2, 1), []float64{1, 0, 0, 0, 0}); !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("Expected ErrNotPositiveDefinite, got %v", err)
+	}
+	if _, err := ConjugateGradient(laplacian, b[:10]); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := ConjugateGradient(laplacian, b, WithMaxIterations(0)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestNonsymmetricSolvers(t *testing.T) {
+	// Convection–diffusion: diagonally dominant but not symmetric
+	a := tridiagonal(300, -1.4, 3, -0.6)
+	b := make([]float64, 300)
+	for i := range b {
+		b[i] = 1
+	}
+	jacobi, err := NewJacobi(a)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ilu, _ := NewILU(a)
+
+	// Test case 1: BiCGSTAB with and without preconditioning
+	for _, opts := range [][]Option{nil, {WithPreconditioner(jacobi)}, {WithPreconditioner(ilu)}} {
+		result, err := BiCGSTAB(a, b, opts...)
+		if err != nil || result.Residual > 1e-10 {
+			t.Fatalf("Expected convergence, got %+v (err %v)", result, err)
+		}
+		checkSolution(t, a, result.Solution, b, 1e-8)
+	}
+
+	// Test case 2: Restarted GMRES, including restarts shorter than the solve
+	for _, opts := range [][]Option{nil, {WithRestart(5)}, {WithPreconditioner(ilu), WithOut(make([]float64, 300))}} {
+		result, err := GMRES(a, b, opts...)
+		if err != nil || result.Residual > 1e-10 {
+			t.Fatalf("Expected convergence, got %+v (err %v)", result, err)
+		}
+		checkSolution(t, a, result.Solution, b, 1e-8)
+	}
+
+	// Test case 3: A zero right-hand side gives the zero solution
+	result, err := GMRES(a, make([]float64, 300), WithInitialGuess(b))
+	if err != nil || result.Iterations != 0 || !compareSlices(result.Solution, make([]float64, 300), 0) {
+		t.Errorf("Expected the zero solution, got %+v (err %v)", result, err)
+	}
+
+	// Test case 4: Preconditioners of singular or mismatched matrices
+	singular, _ := NewCSRFromMatrix([][]float64{{0, 1}, {1, 0}})
+	if _, err := NewJacobi(singular); !errors.Is(err, ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	if _, err := NewILU(singular); !errors.Is(err, ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	if _, err := GMRES(singular, []float64{1, 1}, WithPreconditioner(ilu)); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package litearray
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// logger receives rounding traces. It is nil by default so tracing costs nothing.
+var logger atomic.Pointer[slog.Logger]
+
+// SetLogger installs a logger that traces the rounding decisions made by every function in the
+// package. Traces are emitted at slog.LevelDebug with the operation name, the input shapes and
+// the value of each element before and after rounding. Passing nil turns tracing off again,
+// which is the default. WithLogger overrides the logger for a single call.
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+// shapesOf returns the shapes of one-dimensional arrays for use in traces.
+func shapesOf[T element](arrays ...[]T) [][]int {
+	shapes := make([][]int, len(arrays))
+	for i, array := range arrays {
+		shapes[i] = []int{len(array)}
+	}
+	return shapes
+}
@@ -0,0 +1,43 @@
+package litearray
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(nil)
+
+	// Test case 1: Rounding decisions are traced with the operation and shapes
+	_, err := AddArrays(2, []float64{1.125, 2.5}, []float64{1, 1})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "op=AddArrays") || !strings.Contains(output, "shapes=\"[[2] [2]]\"") {
+		t.Errorf("Expected trace with op and shapes, got %q", output)
+	}
+	if !strings.Contains(output, "index=0 before=2.125 after=2.13") {
+		t.Errorf("Expected per-element trace, got %q", output)
+	}
+
+	// Test case 2: Loggers above debug level receive nothing
+	buf.Reset()
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	_, _ = MeanArrays(2, []float64{1, 2}, []float64{3, 4})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output at info level, got %q", buf.String())
+	}
+
+	// Test case 3: No rounding means no trace
+	buf.Reset()
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	_, _ = AddArrays(-1, []float64{1, 2}, []float64{3, 4})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output without rounding, got %q", buf.String())
+	}
+}
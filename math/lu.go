@@ -0,0 +1,288 @@
+package litearray
+
+import "math"
+
+// luFactors holds an LU factorization with partial pivoting in compact form: the strictly lower
+// triangle of lu holds L without its unit diagonal and the upper triangle holds U.
+type luFactors struct {
+	lu       [][]float64
+	perm     []int   // perm[i] is the row of the original matrix moved to row i
+	sign     float64 // determinant of the permutation, 1 or -1
+	singular int     // first column without a non-zero pivot, or -1
+}
+
+// LU factorizes a square matrix with partial pivoting so that P·A = L·U, where P is a
+// permutation matrix, L is unit lower triangular and U is upper triangular. Rows are swapped to
+// bring the largest remaining entry of each column onto the diagonal, so matrices such as
+// [[0 1] [1 0]] factorize without trouble. Singular matrices factorize too, leaving a zero on the
+// diagonal of U.
+func LU(matrix [][]float64) (p, l, u [][]float64, err error) {
+	f, err := factorLU("LU", matrix)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	n := len(matrix)
+	p, l, u = newMatrix(n, n), newMatrix(n, n), newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		p[i][f.perm[i]] = 1
+		for j := 0; j < n; j++ {
+			switch {
+			case j < i:
+				l[i][j] = f.lu[i][j]
+			case j == i:
+				l[i][j] = 1
+				u[i][j] = f.lu[i][j]
+			default:
+				u[i][j] = f.lu[i][j]
+			}
+		}
+	}
+	return p, l, u, nil
+}
+
+// Solve solves the linear system A·x = b for x using an LU factorization of A. A singular A is
+// reported as ErrSingular.
+func Solve(a [][]float64, b []float64, opts ...Option) ([]float64, error) {
+	const op = "Solve"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := factorLU(op, a)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != len(a) {
+		return nil, shapeError(op, [][]int{{len(a), len(a)}, {len(b)}}, "right-hand side has length %d, expected %d", len(b), len(a))
+	}
+	if err := f.checkSingular(op, "matrix is singular and the system has no unique solution"); err != nil {
+		return nil, err
+	}
+
+	x, err := c.result(op, len(b))
+	if err != nil {
+		return nil, err
+	}
+	f.solve(x, b)
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{len(a), len(a)}, {len(b)}}, x)
+
+	return x, nil
+}
+
+// SolveMatrix solves A·X = B for X, treating each column of B as a right-hand side. A is
+// factorized once for all of them. With WithOut the rows of X share the given buffer.
+func SolveMatrix(a, b [][]float64, opts ...Option) ([][]float64, error) {
+	const op = "SolveMatrix"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := factorLU(op, a)
+	if err != nil {
+		return nil, err
+	}
+	n := len(a)
+	k, err := checkRectangular(op, b)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != n {
+		return nil, shapeError(op, [][]int{{n, n}, {len(b), k}}, "right-hand side has %d rows, expected %d", len(b), n)
+	}
+	if err := f.checkSingular(op, "matrix is singular and the system has no unique solution"); err != nil {
+		return nil, err
+	}
+
+	data, err := c.result(op, n*k)
+	if err != nil {
+		return nil, err
+	}
+	x := rowsOf(data, n, k)
+
+	// Solve for one column of B at a time
+	column := make([]float64, n)
+	solution := make([]float64, n)
+	for j := 0; j < k; j++ {
+		for i := 0; i < n; i++ {
+			column[i] = b[i][j]
+		}
+		f.solve(solution, column)
+		for i := 0; i < n; i++ {
+			x[i][j] = solution[i]
+		}
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{n, n}, {n, k}}, data)
+
+	return x, nil
+}
+
+// factorLU computes the LU factorization of a square matrix with partial pivoting, checking that
+// every row has as many entries as the matrix has rows.
+func factorLU(op string, matrix [][]float64) (*luFactors, error) {
+	if err := checkSquare(op, matrix); err != nil {
+		return nil, err
+	}
+	n := len(matrix)
+
+	// Work on a copy backed by a single buffer
+	lu := newMatrix(n, n)
+	for i := range matrix {
+		copy(lu[i], matrix[i])
+	}
+	f := &luFactors{lu: lu, perm: make([]int, n), sign: 1, singular: -1}
+	for i := range f.perm {
+		f.perm[i] = i
+	}
+
+	for k := 0; k < n; k++ {
+		// Bring the largest entry of the column onto the diagonal
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu[i][k]) > math.Abs(lu[pivot][k]) {
+				pivot = i
+			}
+		}
+		if pivot != k {
+			lu[pivot], lu[k] = lu[k], lu[pivot]
+			f.perm[pivot], f.perm[k] = f.perm[k], f.perm[pivot]
+			f.sign = -f.sign
+		}
+
+		// A zero pivot leaves nothing to eliminate in this column
+		if lu[k][k] == 0 {
+			if f.singular < 0 {
+				f.singular = k
+			}
+			continue
+		}
+
+		// Eliminate the entries below the pivot, storing the multipliers in their place
+		for i := k + 1; i < n; i++ {
+			lu[i][k] /= lu[k][k]
+			factor := lu[i][k]
+			if factor == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				lu[i][j] -= factor * lu[k][j]
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// checkSingular returns a ValueError wrapping ErrSingular when U has a zero on its diagonal.
+func (f *luFactors) checkSingular(op, msg string) error {
+	if f.singular < 0 {
+		return nil
+	}
+	return valueError(op, ErrSingular, f.singular, 0, "%s", msg)
+}
+
+// determinant returns the product of the diagonal of U with the sign of the permutation.
+func (f *luFactors) determinant() float64 {
+	det := f.sign
+	for i := range f.lu {
+		det *= f.lu[i][i]
+	}
+	return det
+}
+
+// solve writes the solution of A·x = b to x by forward substitution with L and back substitution
+// with U. It assumes the factorization is not singular. x and b may be the same slice.
+func (f *luFactors) solve(x, b []float64) {
+	n := len(f.lu)
+
+	// Apply the row permutation; b is read in full first so x may alias it
+	permuted := make([]float64, n)
+	for i, row := range f.perm {
+		permuted[i] = b[row]
+	}
+
+	// Forward substitution with the unit lower triangle
+	for i := 0; i < n; i++ {
+		sum := permuted[i]
+		for j := 0; j < i; j++ {
+			sum -= f.lu[i][j] * permuted[j]
+		}
+		permuted[i] = sum
+	}
+
+	// Back substitution with the upper triangle
+	for i := n - 1; i >= 0; i-- {
+		sum := permuted[i]
+		for j := i + 1; j < n; j++ {
+			sum -= f.lu[i][j] * permuted[j]
+		}
+		permuted[i] = sum / f.lu[i][i]
+	}
+	copy(x, permuted)
+}
+
+// inverse returns the inverse of the factorized matrix. It assumes the factorization is not
+// singular.
+func (f *luFactors) inverse() [][]float64 {
+	// Column j of the inverse solves A·x = e_j
+	n := len(f.lu)
+	inverse := newMatrix(n, n)
+	column := make([]float64, n)
+	for j := 0; j < n; j++ {
+		clear(column)
+		column[j] = 1
+		f.solve(column, column)
+		for i := 0; i < n; i++ {
+			inverse[i][j] = column[i]
+		}
+	}
+	return inverse
+}
+
+// newMatrix returns a zero rows×cols matrix whose rows share a single buffer.
+func newMatrix(rows, cols int) [][]float64 {
+	return rowsOf(make([]float64, rows*cols), rows, cols)
+}
+
+// rowsOf slices a row-major buffer into rows×cols rows.
+func rowsOf[T any](data []T, rows, cols int) [][]T {
+	matrix := make([][]T, rows)
+	for i := range matrix {
+		matrix[i] = data[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return matrix
+}
+
+// checkRectangular checks that matrix is non-empty and all of its rows have the same length,
+// returning that length.
+func checkRectangular[T element](op string, matrix [][]T) (int, error) {
+	if len(matrix) == 0 {
+		return 0, emptyError(op, matrixShapes(matrix), "matrix cannot be empty")
+	}
+	width := len(matrix[0])
+	for _, row := range matrix {
+		if len(row) != width {
+			return 0, shapeError(op, matrixShapes(matrix), "all rows in the matrix must have the same length")
+		}
+	}
+	return width, nil
+}
+
+// checkSquare checks that matrix is non-empty and every row has as many entries as it has rows.
+func checkSquare[T element](op string, matrix [][]T) error {
+	if len(matrix) == 0 {
+		return squareError(op, matrix)
+	}
+	for _, row := range matrix {
+		if len(row) != len(matrix) {
+			return squareError(op, matrix)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// multiplyMatrices returns the product of two matrices for checking factorizations.
+func multiplyMatrices(a, b [][]float64) [][]float64 {
+	result := newMatrix(len(a), len(b[0]))
+	for i := range a {
+		for k := range b {
+			for j := range b[k] {
+				result[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return result
+}
+
+// compareMatrices compares two matrices for equality within a tolerance, matching NaN like
+// compareSlices.
+func compareMatrices(a, b [][]float64, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !compareSlices(a[i], b[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLU(t *testing.T) {
+	// Test case 1: P·A = L·U for a matrix that needs row swaps
+	matrix := [][]float64{{1, 2, 3, 4}, {2, 4, 1, 0}, {0, 1, 5, 2}, {3, 1, 0, 7}}
+	p, l, u, err := LU(matrix)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !compareMatrices(multiplyMatrices(p, matrix), multiplyMatrices(l, u), 1e-12) {
+		t.Errorf("Expected P·A = L·U, got P=%v L=%v U=%v", p, l, u)
+	}
+	for i := range l {
+		if l[i][i] != 1 {
+			t.Errorf("Expected a unit diagonal in L, got %v", l)
+		}
+		for j := i + 1; j < len(l); j++ {
+			if l[i][j] != 0 || u[j][i] != 0 {
+				t.Errorf("Expected triangular factors, got L=%v U=%v", l, u)
+			}
+		}
+	}
+
+	// Test case 2: A zero on the diagonal is pivoted away
+	p, _, u, err = LU([][]float64{{0, 1}, {1, 0}})
+	if err != nil || !compareMatrices(p, [][]float64{{0, 1}, {1, 0}}, 0) || !compareMatrices(u, [][]float64{{1, 0}, {0, 1}}, 0) {
+		t.Errorf("Expected a swap and U = I, got P=%v U=%v (err %v)", p, u, err)
+	}
+
+	// Test case 3: Singular matrices still factorize
+	_, _, u, err = LU([][]float64{{1, 2}, {2, 4}})
+	if err != nil || u[1][1] != 0 {
+		t.Errorf("Expected a zero pivot, got U=%v (err %v)", u, err)
+	}
+
+	// Test case 4: Ragged rows
+	_, _, _, err = LU([][]float64{{1, 2}, {3}})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	a := [][]float64{{0, 2, 1}, {1, 1, 1}, {2, 1, 0}}
+
+	// Test case 1: Single right-hand side
+	x, err := Solve(a, []float64{5, 4, 4})
+	if err != nil || !compareSlices(x, []float64{1, 2, 1}, 1e-12) {
+		t.Errorf("Expected [1 2 1], got %v (err %v)", x, err)
+	}
+
+	// Test case 2: Multiple right-hand sides share one factorization
+	xs, err := SolveMatrix(a, [][]float64{{5, 0}, {4, 1}, {4, 2}}, WithPrecision(6))
+	if err != nil || !compareMatrices(xs, [][]float64{{1, 1}, {2, 0}, {1, 0}}, 0) {
+		t.Errorf("Expected [[1 1] [2 0] [1 0]], got %v (err %v)", xs, err)
+	}
+
+	// Test case 3: Singular systems
+	_, err = Solve([][]float64{{1, 2}, {2, 4}}, []float64{1, 2})
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+
+	// Test case 4: Mismatched right-hand sides
+	_, err = Solve(a, []float64{1, 2})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	_, err = SolveMatrix(a, [][]float64{{1}, {2}})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch for matrix right-hand side, got %v", err)
+	}
+}
+
+func TestPivotedInverseAndDeterminant(t *testing.T) {
+	// Test case 1: Inverting a matrix with a zero on the diagonal
+	inverse, err := InversionMatrix([][]float64{{0, 1}, {1, 0}})
+	if err != nil || !compareMatrices(inverse, [][]float64{{0, 1}, {1, 0}}, 0) {
+		t.Errorf("Expected [[0 1] [1 0]], got %v (err %v)", inverse, err)
+	}
+
+	// Test case 2: Row swaps flip the sign of the determinant
+	det, err := DeterminantMatrix([][]float64{{0, 1, 0}, {1, 0, 0}, {0, 0, 2}})
+	if err != nil || det != -2 {
+		t.Errorf("Expected -2, got %v (err %v)", det, err)
+	}
+
+	// Test case 3: Singular matrices have a zero determinant
+	det, err = DeterminantMatrix([][]float64{{1, 2}, {2, 4}})
+	if err != nil || det != 0 {
+		t.Errorf("Expected 0, got %v (err %v)", det, err)
+	}
+
+	// Test case 4: Invertible matrices are not singular however badly they are scaled
+	for _, matrix := range [][][]float64{{{1e-17, 0}, {0, 1}}, {{1e20, 0}, {0, 1}}, {{1e5, 0}, {0, 1e-12}}} {
+		want := matrix[0][0] * matrix[1][1]
+		if det, err := DeterminantMatrix(matrix); err != nil || det != want {
+			t.Errorf("Expected %v, got %v (err %v)", want, det, err)
+		}
+		inverse, err := InversionMatrix(matrix)
+		if err != nil || inverse[0][0] != 1/matrix[0][0] || inverse[1][1] != 1/matrix[1][1] {
+			t.Errorf("Expected the reciprocal diagonal of %v, got %v (err %v)", matrix, inverse, err)
+		}
+		if _, err := Solve(matrix, []float64{1, 1}); err != nil {
+			t.Errorf("Expected a solution for %v, got %v", matrix, err)
+		}
+	}
+	sign, logDet, err := LogDeterminantMatrix([][]float64{{1e-17, 0}, {0, 1}})
+	if err != nil || sign != 1 || math.Abs(logDet-math.Log(1e-17)) > 1e-12 {
+		t.Errorf("Expected (1, %v), got (%v, %v) (err %v)", math.Log(1e-17), sign, logDet, err)
+	}
+
+	// Test case 5: Near-singularity is left to the rank, which has a tolerance
+	if rank, err := MatrixRank([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}); err != nil || rank != 2 {
+		t.Errorf("Expected rank 2, got %v (err %v)", rank, err)
+	}
+}
@@ -0,0 +1,523 @@
+package litearray
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// eigenConditionLimit is the largest condition number of the eigenvector matrix for which a
+// matrix function is computed through the eigen decomposition. Errors in the eigenvalues are
+// amplified by up to this factor, so worse-conditioned matrices use a direct algorithm instead.
+const eigenConditionLimit = 1e3
+
+// MatrixFunctionResult holds the result of a matrix function together with diagnostics that
+// describe how accurate it is likely to be.
+type MatrixFunctionResult struct {
+	// Matrix is the result, rounded according to the call's options.
+	Matrix [][]float64
+	// Method names the algorithm used: "symmetric eigen", "eigen", "padé", "denman-beavers",
+	// "inverse scaling and squaring" or "binary powering".
+	Method string
+	// Residual is the relative error, in the Frobenius norm, of an identity the exact result
+	// satisfies. Each function documents its identity. It is computed before rounding.
+	Residual float64
+	// Condition is the 1-norm condition number of the eigenvector matrix: 1 for the symmetric
+	// eigen method, at most 1e3 for the eigen method, and NaN for the other methods.
+	Condition float64
+	// Imaginary is the largest imaginary part dropped when forming the real result from complex
+	// eigenvalues. It is zero unless Method is "eigen".
+	Imaginary float64
+}
+
+// scalarFunction describes how a matrix function acts on eigenvalues, and how to compute it
+// when the eigen decomposition is unreliable.
+type scalarFunction struct {
+	real     func(float64) float64
+	complex  func(complex128) complex128
+	check    func(op string, k int, lambda complex128) error
+	fallback func(c *config, op string, a [][]float64) ([][]float64, string, error)
+}
+
+// MatrixExp calculates the matrix exponential e^A of a square matrix, which solves the linear
+// system of ODEs x′ = A·x as x(t) = e^(At)·x(0) and turns a Markov generator into transition
+// probabilities. The residual is ‖e^A·e^(−A) − I‖/√n.
+func MatrixExp(matrix [][]float64, opts ...Option) (*MatrixFunctionResult, error) {
+	const op = "MatrixExp"
+	c, err := matrixFunctionConfig(op, matrix, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.matrixFunction(op, matrix, expFunction)
+	if err != nil {
+		return nil, err
+	}
+	negated := scaleMatrix(matrix, -1)
+	inverse, err := c.matrixFunction(op, negated, expFunction)
+	if err != nil {
+		return nil, err
+	}
+	n := len(matrix)
+	result.Residual = frobenius(subtractIdentity(c.product(result.Matrix, inverse.Matrix))) / math.Sqrt(float64(n))
+
+	return c.finish(op, matrix, result)
+}
+
+// MatrixLog calculates the principal matrix logarithm of a square matrix, the inverse of
+// MatrixExp whose eigenvalues have imaginary parts in (−π, π). A real logarithm exists only when
+// the matrix has no eigenvalues on the closed negative real axis: a zero eigenvalue is reported
+// as ErrSingular and a negative one as ErrDomain. The residual is ‖e^X − A‖/‖A‖.
+func MatrixLog(matrix [][]float64, opts ...Option) (*MatrixFunctionResult, error) {
+	const op = "MatrixLog"
+	c, err := matrixFunctionConfig(op, matrix, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.matrixFunction(op, matrix, logFunction)
+	if err != nil {
+		return nil, err
+	}
+	exp, err := c.matrixFunction(op, result.Matrix, expFunction)
+	if err != nil {
+		return nil, err
+	}
+	result.Residual = relativeDistance(exp.Matrix, matrix)
+
+	return c.finish(op, matrix, result)
+}
+
+// MatrixSqrt calculates the principal square root of a square matrix, the unique X with X·X = A
+// whose eigenvalues have positive real parts. A matrix with a negative real eigenvalue has no real
+// principal square root, which is reported as ErrDomain. The residual is ‖X·X − A‖/‖A‖.
+func MatrixSqrt(matrix [][]float64, opts ...Option) (*MatrixFunctionResult, error) {
+	const op = "MatrixSqrt"
+	c, err := matrixFunctionConfig(op, matrix, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.matrixFunction(op, matrix, sqrtFunction)
+	if err != nil {
+		return nil, err
+	}
+	result.Residual = relativeDistance(c.product(result.Matrix, result.Matrix), matrix)
+
+	return c.finish(op, matrix, result)
+}
+
+// MatrixPower raises a square matrix to the power p. Integer powers are computed by repeated
+// squaring, with negative ones raising the inverse, so they work for any matrix that is invertible
+// when p < 0. Other powers are computed as the principal power through the eigen decomposition
+// or as e^(p·log A), with the same restrictions as MatrixLog. Since Aᵖ commutes with A, the
+// residual is ‖A·X − X·A‖/(‖A‖·‖X‖).
+func MatrixPower(matrix [][]float64, p float64, opts ...Option) (*MatrixFunctionResult, error) {
+	const op = "MatrixPower"
+	c, err := matrixFunctionConfig(op, matrix, opts)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(p) || math.IsInf(p, 0) {
+		return nil, argumentError(op, "exponent must be finite, got %g", p)
+	}
+
+	var result *MatrixFunctionResult
+	if p == math.Trunc(p) && math.Abs(p) <= 1<<53 {
+		x, err := c.integerPower(op, matrix, int64(p))
+		if err != nil {
+			return nil, err
+		}
+		result = &MatrixFunctionResult{Matrix: x, Method: "binary powering", Condition: math.NaN()}
+	} else {
+		result, err = c.matrixFunction(op, matrix, powerFunction(p))
+		if err != nil {
+			return nil, err
+		}
+	}
+	commutator := subtractMatrices(c.product(matrix, result.Matrix), c.product(result.Matrix, matrix))
+	if scale := frobenius(matrix) * frobenius(result.Matrix); scale > 0 {
+		result.Residual = frobenius(commutator) / scale
+	}
+
+	return c.finish(op, matrix, result)
+}
+
+var expFunction = scalarFunction{
+	real:    math.Exp,
+	complex: cmplx.Exp,
+	fallback: func(c *config, op string, a [][]float64) ([][]float64, string, error) {
+		return padeExp(a), "padé", nil
+	},
+}
+
+var logFunction = scalarFunction{
+	real:    math.Log,
+	complex: cmplx.Log,
+	check:   checkLogDomain,
+	fallback: func(c *config, op string, a [][]float64) ([][]float64, string, error) {
+		x, err := c.inverseScalingSquaring(op, a)
+		return x, "inverse scaling and squaring", err
+	},
+}
+
+var sqrtFunction = scalarFunction{
+	real:    math.Sqrt,
+	complex: cmplx.Sqrt,
+	check: func(op string, k int, lambda complex128) error {
+		if imag(lambda) == 0 && real(lambda) < 0 {
+			return valueError(op, ErrDomain, k, real(lambda), "matrix has the negative eigenvalue %g and no real principal square root", real(lambda))
+		}
+		return nil
+	},
+	fallback: func(c *config, op string, a [][]float64) ([][]float64, string, error) {
+		x, err := c.denmanBeavers(op, a)
+		return x, "denman-beavers", err
+	},
+}
+
+// powerFunction returns the principal power p of eigenvalues, for non-integer p.
+func powerFunction(p float64) scalarFunction {
+	return scalarFunction{
+		real:    func(x float64) float64 { return math.Pow(x, p) },
+		complex: func(x complex128) complex128 { return cmplx.Pow(x, complex(p, 0)) },
+		check:   checkLogDomain,
+		fallback: func(c *config, op string, a [][]float64) ([][]float64, string, error) {
+			log, err := c.inverseScalingSquaring(op, a)
+			if err != nil {
+				return nil, "", err
+			}
+			return padeExp(scaleMatrix(log, p)), "inverse scaling and squaring", nil
+		},
+	}
+}
+
+// checkLogDomain rejects eigenvalues on the closed negative real axis, where the principal
+// logarithm is not real.
+func checkLogDomain(op string, k int, lambda complex128) error {
+	switch {
+	case lambda == 0:
+		return valueError(op, ErrSingular, k, 0, "matrix is singular and has no logarithm")
+	case imag(lambda) == 0 && real(lambda) < 0:
+		return valueError(op, ErrDomain, k, real(lambda), "matrix has the negative eigenvalue %g and no real principal logarithm", real(lambda))
+	}
+	return nil
+}
+
+// matrixFunctionConfig validates the options and the matrix shared by the matrix functions.
+func matrixFunctionConfig(op string, matrix [][]float64, opts []Option) (*config, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSquare(op, matrix); err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// matrixFunction applies fn to a square matrix. Exactly symmetric matrices go through their
+// orthogonal eigen decomposition. Other matrices go through their eigen decomposition when the
+// eigenvectors are well-conditioned, and through fn.fallback otherwise.
+func (c *config) matrixFunction(op string, matrix [][]float64, fn scalarFunction) (*MatrixFunctionResult, error) {
+	n := len(matrix)
+	if isSymmetric(matrix) {
+		values, vectors, err := EigenSymmetric(matrix)
+		if err != nil {
+			return nil, err
+		}
+		for k, lambda := range values {
+			if fn.check != nil {
+				if err := fn.check(op, k, complex(lambda, 0)); err != nil {
+					return nil, err
+				}
+			}
+			values[k] = fn.real(lambda)
+		}
+
+		// X = V·f(Λ)·Vᵀ
+		x := newMatrix(n, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				sum := 0.0
+				for k, value := range values {
+					sum += vectors[i][k] * value * vectors[j][k]
+				}
+				x[i][j] = sum
+			}
+		}
+		return &MatrixFunctionResult{Matrix: x, Method: "symmetric eigen", Condition: 1}, nil
+	}
+
+	eig, err := eigen(op, matrix, EigenRight, nil)
+	if err != nil {
+		return nil, err
+	}
+	if fn.check != nil {
+		for k, lambda := range eig.Values {
+			if err := fn.check(op, k, lambda); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Fall back when the eigenvectors are close to linearly dependent
+	inverse, ok := invertComplex(eig.Right)
+	condition := math.Inf(1)
+	if ok {
+		condition = complexNormOne(eig.Right) * complexNormOne(inverse)
+	}
+	if condition > eigenConditionLimit {
+		x, method, err := fn.fallback(c, op, matrix)
+		if err != nil {
+			return nil, err
+		}
+		return &MatrixFunctionResult{Matrix: x, Method: method, Condition: math.NaN()}, nil
+	}
+
+	// X = V·f(Λ)·V⁻¹, which is real up to rounding because complex eigenvalues come in
+	// conjugate pairs
+	values := make([]complex128, n)
+	for k, lambda := range eig.Values {
+		values[k] = fn.complex(lambda)
+	}
+	result := &MatrixFunctionResult{Matrix: newMatrix(n, n), Method: "eigen", Condition: condition}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var sum complex128
+			for k, value := range values {
+				sum += eig.Right[i][k] * value * inverse[k][j]
+			}
+			result.Matrix[i][j] = real(sum)
+			result.Imaginary = max(result.Imaginary, math.Abs(imag(sum)))
+		}
+	}
+	return result, nil
+}
+
+// finish rounds the result of a matrix function according to the call's options.
+func (c *config) finish(op string, matrix [][]float64, result *MatrixFunctionResult) (*MatrixFunctionResult, error) {
+	n := len(matrix)
+	data, err := c.result(op, n*n)
+	if err != nil {
+		return nil, err
+	}
+	copy(data, flatten(result.Matrix, n))
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), data)
+
+	result.Matrix = rowsOf(data, n, n)
+	return result, nil
+}
+
+// integerPower raises a square matrix to an integer power by repeated squaring, inverting it
+// first when p is negative.
+func (c *config) integerPower(op string, matrix [][]float64, p int64) ([][]float64, error) {
+	base := matrix
+	if p < 0 {
+		f, err := factorLU(op, matrix)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.checkSingular(op, "matrix is singular and cannot be raised to a negative power"); err != nil {
+			return nil, err
+		}
+		base, p = f.inverse(), -p
+	}
+
+	result := identity(len(matrix))
+	for ; p > 0; p >>= 1 {
+		if p&1 == 1 {
+			result = c.product(result, base)
+		}
+		if p > 1 {
+			base = c.product(base, base)
+		}
+	}
+	return result, nil
+}
+
+// denmanBeavers calculates the principal square root of a matrix with the Denman–Beavers
+// iteration Yₖ₊₁ = (Yₖ + Zₖ⁻¹)/2, Zₖ₊₁ = (Zₖ + Yₖ⁻¹)/2, where Yₖ converges quadratically to √A.
+func (c *config) denmanBeavers(op string, a [][]float64) ([][]float64, error) {
+	const maxIterations = 100
+	y, z := a, identity(len(a))
+	converged := false
+	for iteration := 1; iteration <= maxIterations; iteration++ {
+		yInverse, err := invert(op, y)
+		if err != nil {
+			return nil, err
+		}
+		zInverse, err := invert(op, z)
+		if err != nil {
+			return nil, err
+		}
+		next := scaleMatrix(addMatrices(y, zInverse), 0.5)
+		z = scaleMatrix(addMatrices(z, yInverse), 0.5)
+		change := relativeDistance(next, y)
+		y = next
+
+		// Quadratic convergence makes the step after a change of √ε accurate to ε
+		if converged {
+			return y, nil
+		}
+		converged = change <= math.Sqrt(epsilon)
+	}
+	return nil, &ConvergenceError{Op: op, Iterations: maxIterations, Residual: math.NaN(), msg: "square root iteration did not converge"}
+}
+
+// inverseScalingSquaring calculates the principal logarithm of a matrix by taking square roots
+// until it is close to the identity, summing the series log(I + E) = E − E²/2 + E³/3 − … there,
+// and scaling the result back up.
+func (c *config) inverseScalingSquaring(op string, a [][]float64) ([][]float64, error) {
+	const maxRoots, maxTerms = 64, 200
+	x, roots := a, 0
+	for normOne(subtractIdentity(x)) > 0.25 {
+		if roots == maxRoots {
+			return nil, &ConvergenceError{Op: op, Iterations: roots, Residual: normOne(subtractIdentity(x)), msg: "logarithm did not approach the identity after repeated square roots"}
+		}
+		root, err := c.denmanBeavers(op, x)
+		if err != nil {
+			return nil, err
+		}
+		x, roots = root, roots+1
+	}
+
+	// With ‖E‖ ≤ 1/4 every term is at most a quarter of the previous one
+	e := subtractIdentity(x)
+	log, power := e, e
+	for k := 2; k <= maxTerms; k++ {
+		power = c.product(power, e)
+		term := scaleMatrix(power, math.Pow(-1, float64(k+1))/float64(k))
+		log = addMatrices(log, term)
+		if frobenius(term) <= epsilon*frobenius(log) {
+			break
+		}
+	}
+	return scaleMatrix(log, math.Ldexp(1, roots)), nil
+}
+
+// padeExp calculates the matrix exponential with Gonum's scaling and squaring Padé approximant.
+func padeExp(a [][]float64) [][]float64 {
+	var exp mat.Dense
+	exp.Exp(denseOf(a))
+	return rowsOfDense(&exp)
+}
+
+// invert returns the inverse of a square matrix, reporting a singular matrix as ErrSingular.
+func invert(op string, matrix [][]float64) ([][]float64, error) {
+	f, err := factorLU(op, matrix)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.checkSingular(op, "matrix is singular and cannot be inverted"); err != nil {
+		return nil, err
+	}
+	return f.inverse(), nil
+}
+
+// invertComplex inverts a square complex matrix through its LU factorization. It reports false
+// when the matrix is singular.
+func invertComplex(matrix [][]complex128) ([][]complex128, bool) {
+	f := decomposeComplex(matrix)
+	if f.singular >= 0 {
+		return nil, false
+	}
+	return f.inverse(), true
+}
+
+// complexNormOne returns the largest absolute column sum of a square complex matrix.
+func complexNormOne(matrix [][]complex128) float64 {
+	norm := 0.0
+	for j := range matrix {
+		sum := 0.0
+		for i := range matrix {
+			sum += cmplx.Abs(matrix[i][j])
+		}
+		norm = max(norm, sum)
+	}
+	return norm
+}
+
+// product returns the matrix product of two matrices with matching inner dimensions.
+func (c *config) product(a, b [][]float64) [][]float64 {
+	m, k, n := len(a), len(b), len(b[0])
+	data := make([]float64, m*n)
+	c.gemm(m, n, k, flatten(a, k), flatten(b, n), data)
+	return rowsOf(data, m, n)
+}
+
+// isSymmetric reports whether a square matrix equals its transpose exactly.
+func isSymmetric(matrix [][]float64) bool {
+	for i := range matrix {
+		for j := i + 1; j < len(matrix); j++ {
+			if matrix[i][j] != matrix[j][i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// scaleMatrix returns factor·A.
+func scaleMatrix(a [][]float64, factor float64) [][]float64 {
+	result := newMatrix(len(a), len(a[0]))
+	for i, row := range a {
+		floats.ScaleTo(result[i], factor, row)
+	}
+	return result
+}
+
+// addMatrices returns A + B for matrices of the same shape.
+func addMatrices(a, b [][]float64) [][]float64 {
+	result := newMatrix(len(a), len(a[0]))
+	for i := range a {
+		floats.AddTo(result[i], a[i], b[i])
+	}
+	return result
+}
+
+// subtractMatrices returns A − B for matrices of the same shape.
+func subtractMatrices(a, b [][]float64) [][]float64 {
+	result := newMatrix(len(a), len(a[0]))
+	for i := range a {
+		floats.SubTo(result[i], a[i], b[i])
+	}
+	return result
+}
+
+// subtractIdentity returns A − I for a square matrix.
+func subtractIdentity(a [][]float64) [][]float64 {
+	return subtractMatrices(a, identity(len(a)))
+}
+
+// frobenius returns the Frobenius norm of a matrix.
+func frobenius(a [][]float64) float64 {
+	return floats.Norm(flatten(a, len(a[0])), 2)
+}
+
+// normOne returns the largest absolute column sum of a matrix.
+func normOne(a [][]float64) float64 {
+	sums := make([]float64, len(a[0]))
+	for _, row := range a {
+		for j, v := range row {
+			sums[j] += math.Abs(v)
+		}
+	}
+	return floats.Max(sums)
+}
+
+// relativeDistance returns ‖X − A‖/‖A‖ in the Frobenius norm, or ‖X‖ when A is zero.
+func relativeDistance(x, a [][]float64) float64 {
+	distance := frobenius(subtractMatrices(x, a))
+	if scale := frobenius(a); scale > 0 {
+		return distance / scale
+	}
+	return distance
+}
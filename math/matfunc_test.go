@@ -0,0 +1,119 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMatrixExp(t *testing.T) {
+	// Test case 1: Diagonal matrices exponentiate entry by entry
+	result, err := MatrixExp([][]float64{{1, 0}, {0, 2}})
+	if err != nil || !compareMatrices(result.Matrix, [][]float64{{math.E, 0}, {0, math.Exp(2)}}, 1e-12) || result.Method != "symmetric eigen" {
+		t.Errorf("Expected [[e 0] [0 e²]] by symmetric eigen, got %+v (err %v)", result, err)
+	}
+
+	// Test case 2: A rotation generator gives a rotation, through complex eigenvalues
+	theta := 0.5
+	result, err = MatrixExp([][]float64{{0, -theta}, {theta, 0}})
+	expected := [][]float64{{math.Cos(theta), -math.Sin(theta)}, {math.Sin(theta), math.Cos(theta)}}
+	if err != nil || !compareMatrices(result.Matrix, expected, 1e-12) || result.Method != "eigen" || result.Imaginary > 1e-15 {
+		t.Errorf("Expected a rotation by 0.5 by eigen, got %+v (err %v)", result, err)
+	}
+
+	// Test case 3: A defective matrix falls back to Padé
+	result, err = MatrixExp([][]float64{{1, 1}, {0, 1}})
+	if err != nil || !compareMatrices(result.Matrix, [][]float64{{math.E, math.E}, {0, math.E}}, 1e-12) || result.Method != "padé" {
+		t.Errorf("Expected [[e e] [0 e]] by Padé, got %+v (err %v)", result, err)
+	}
+	if result.Residual > 1e-14 || !math.IsNaN(result.Condition) {
+		t.Errorf("Expected a tiny residual and no condition number, got %+v", result)
+	}
+
+	// Test case 4: Markov generator rows of the exponential sum to one
+	result, err = MatrixExp([][]float64{{-0.3, 0.2, 0.1}, {0.4, -0.5, 0.1}, {0, 0.6, -0.6}}, WithPrecision(10))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, row := range result.Matrix {
+		if math.Abs(row[0]+row[1]+row[2]-1) > 1e-9 {
+			t.Errorf("Expected rows summing to one, got %v", result.Matrix)
+		}
+	}
+}
+
+func TestMatrixLogAndSqrt(t *testing.T) {
+	nonNormal := [][]float64{{4, 1}, {0, 9}}
+	defective := [][]float64{{4, 1}, {0, 4}}
+
+	// Test case 1: Logarithm inverts the exponential
+	for _, matrix := range [][][]float64{nonNormal, defective, {{2, 1}, {1, 3}}} {
+		result, err := MatrixLog(matrix)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		exp, _ := MatrixExp(result.Matrix)
+		if !compareMatrices(exp.Matrix, matrix, 1e-10) || result.Residual > 1e-12 {
+			t.Errorf("Expected exp(log(A)) = A for %v, got %+v", matrix, result)
+		}
+	}
+
+	// Test case 2: Square roots square back, including through Denman–Beavers
+	result, err := MatrixSqrt(nonNormal)
+	if err != nil || !compareMatrices(result.Matrix, [][]float64{{2, 0.2}, {0, 3}}, 1e-12) {
+		t.Errorf("Expected [[2 0.2] [0 3]], got %+v (err %v)", result, err)
+	}
+	result, err = MatrixSqrt(defective)
+	if err != nil || !compareMatrices(result.Matrix, [][]float64{{2, 0.25}, {0, 2}}, 1e-12) || result.Method != "denman-beavers" {
+		t.Errorf("Expected [[2 0.25] [0 2]] by Denman–Beavers, got %+v (err %v)", result, err)
+	}
+
+	// Test case 3: No real logarithm or square root
+	_, err = MatrixSqrt([][]float64{{-1, 0}, {0, 4}})
+	if !errors.Is(err, ErrDomain) {
+		t.Errorf("Expected ErrDomain, got %v", err)
+	}
+	_, err = MatrixLog([][]float64{{1, 2}, {2, 4}})
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+}
+
+func TestMatrixPower(t *testing.T) {
+	fibonacci := [][]float64{{1, 1}, {1, 0}}
+
+	// Test case 1: Integer powers by repeated squaring
+	result, err := MatrixPower(fibonacci, 10)
+	if err != nil || !compareMatrices(result.Matrix, [][]float64{{89, 55}, {55, 34}}, 0) || result.Method != "binary powering" {
+		t.Errorf("Expected [[89 55] [55 34]], got %+v (err %v)", result, err)
+	}
+	result, err = MatrixPower(fibonacci, 0)
+	if err != nil || !compareMatrices(result.Matrix, identityMatrix(2), 0) {
+		t.Errorf("Expected the identity, got %+v (err %v)", result, err)
+	}
+	result, err = MatrixPower([][]float64{{2, 0}, {0, 4}}, -2)
+	if err != nil || !compareMatrices(result.Matrix, [][]float64{{0.25, 0}, {0, 0.0625}}, 1e-15) {
+		t.Errorf("Expected [[0.25 0] [0 0.0625]], got %+v (err %v)", result, err)
+	}
+	result, err = MatrixPower([][]float64{{1e20, 0}, {0, 1}}, -1)
+	if err != nil || !compareMatrices(result.Matrix, [][]float64{{1e-20, 0}, {0, 1}}, 0) {
+		t.Errorf("Expected [[1e-20 0] [0 1]], got %+v (err %v)", result, err)
+	}
+
+	// Test case 2: A half power is the square root
+	result, err = MatrixPower([][]float64{{4, 1}, {0, 4}}, 0.5)
+	if err != nil || !compareMatrices(result.Matrix, [][]float64{{2, 0.25}, {0, 2}}, 1e-10) || result.Residual > 1e-12 {
+		t.Errorf("Expected [[2 0.25] [0 2]], got %+v (err %v)", result, err)
+	}
+
+	// Test case 3: Invalid input
+	if _, err := MatrixPower([][]float64{{1, 2}, {2, 4}}, -1); !errors.Is(err, ErrSingular) {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+	if _, err := MatrixPower(fibonacci, math.NaN()); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := MatrixPower([][]float64{{1, 2}}, 2); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
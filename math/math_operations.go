@@ -1,529 +1,611 @@
 package litearray
 
-import (
-	"fmt"
-	"math"
-	"sort"
+import "math"
 
-	"gonum.org/v1/gonum/mat"
-)
+// Add adds arrays element-wise, broadcasting arrays that hold a single element against the others.
+// opts control rounding, the output slice, parallelism and NaN handling.
+func Add(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return add("Add", arrays, opts)
+}
 
 // AddArrays adds multiple arrays element-wise and supports optional rounding to a specified precision.
+// Arrays holding a single element are broadcast against the others, as in NumPy.
+// It is equivalent to Add(arrays, WithPrecision(precision)).
 func AddArrays(precision int, arrays ...[]float64) ([]float64, error) {
-	// Ensure at least two arrays are provided
-	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform addition")
-	}
+	return add("AddArrays", arrays, []Option{WithPrecision(precision)})
+}
 
-	// Check that all arrays are the same length
-	length := len(arrays[0])
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+func add(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
+	// Check that the arrays can be broadcast to a common length
+	length, err := checkOperands(op, arrays, false, "at least two arrays are required to perform addition")
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
+	}
 
-	// Loop through the arrays and perform the addition
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
-		}
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			fmt.Printf("Before Rounding: result[%d] = %f\n", i, result[i])
-			result[i] = math.Round(result[i]*factor) / factor
-			fmt.Printf("After Rounding: result[%d] = %f\n", i, result[i])
+	// Sum each element across the arrays, repeating single-element arrays
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			sum := 0.0
+			for _, array := range arrays {
+				sum += array[i%len(array)]
+			}
+			result[i] = sum
 		}
-	}
+	})
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
 	return result, nil
 }
 
+// Subtract subtracts the remaining arrays from the first element-wise, broadcasting arrays that
+// hold a single element against the others.
+func Subtract(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return subtract("Subtract", arrays, opts)
+}
+
 // SubtractArrays subtracts multiple arrays element-wise and supports optional rounding to a specified precision.
+// Arrays holding a single element are broadcast against the others.
+// It is equivalent to Subtract(arrays, WithPrecision(precision)).
 func SubtractArrays(precision int, arrays ...[]float64) ([]float64, error) {
-	// Ensure at least two arrays are provided
-	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform subtraction")
-	}
+	return subtract("SubtractArrays", arrays, []Option{WithPrecision(precision)})
+}
 
-	// Check that all arrays are the same length
-	length := len(arrays[0])
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+func subtract(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a result slice initialized to zero
-	// This assumes that the first array is not nil and has the same length as the others
-	result := make([]float64, length)
-	// Initialize result to the first array
-	copy(result, arrays[0])
-	if arrays[0] == nil {
-		return nil, fmt.Errorf("the first array cannot be nil")
+	// Check that the arrays can be broadcast to a common length
+	length, err := checkOperands(op, arrays, true, "at least two arrays are required to perform subtraction")
+	if err != nil {
+		return nil, err
 	}
-	if length == 0 {
-		return nil, fmt.Errorf("the first array cannot be empty")
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Loop through the remaining arrays and perform the subtraction
-	for _, array := range arrays[1:] { // Start from the second array
-		for i := range array {
-			result[i] -= array[i]
-		}
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			fmt.Printf("Before Rounding: result[%d] = %f\n", i, result[i])
-			result[i] = math.Round(result[i]*factor) / factor
-			fmt.Printf("After Rounding: result[%d] = %f\n", i, result[i])
+	// Start from the first array and subtract the others, repeating single-element arrays
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			difference := arrays[0][i%len(arrays[0])]
+			for _, array := range arrays[1:] {
+				difference -= array[i%len(array)]
+			}
+			result[i] = difference
 		}
-	}
+	})
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
 	return result, nil
 }
 
+// Multiply multiplies arrays element-wise, broadcasting arrays that hold a single element against the others.
+func Multiply(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return multiply("Multiply", arrays, opts)
+}
+
 // MultiplyArrays multiplies multiple arrays element-wise and supports optional rounding to a specified precision.
+// Arrays holding a single element are broadcast against the others.
+// It is equivalent to Multiply(arrays, WithPrecision(precision)).
 func MultiplyArrays(precision int, arrays ...[]float64) ([]float64, error) {
-	// Ensure at least two arrays are provided
-	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform subtraction")
-	}
+	return multiply("MultiplyArrays", arrays, []Option{WithPrecision(precision)})
+}
 
-	// Check that all arrays are the same length
-	length := len(arrays[0])
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+func multiply(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-	// Initialize result to the first array
-	copy(result, arrays[0])
-	if arrays[0] == nil {
-		return nil, fmt.Errorf("the first array cannot be nil")
+	// Check that the arrays can be broadcast to a common length
+	length, err := checkOperands(op, arrays, true, "at least two arrays are required to perform multiplication")
+	if err != nil {
+		return nil, err
 	}
-	if length == 0 {
-		return nil, fmt.Errorf("the first array cannot be empty")
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Loop through the arrays and perform the multiplication
-	for _, array := range arrays[1:] { // Start from the second array
-		for i := range array {
-			result[i] *= array[i]
-		}
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			fmt.Printf("Before Rounding: result[%d] = %f\n", i, result[i])
-			result[i] = math.Round(result[i]*factor) / factor
-			fmt.Printf("After Rounding: result[%d] = %f\n", i, result[i])
+	// Start from the first array and multiply by the others, repeating single-element arrays
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			product := arrays[0][i%len(arrays[0])]
+			for _, array := range arrays[1:] {
+				product *= array[i%len(array)]
+			}
+			result[i] = product
 		}
-	}
+	})
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
 	return result, nil
 }
 
+// Divide divides the first array by the remaining arrays element-wise, broadcasting arrays that
+// hold a single element against the others. A zero divisor is reported as ErrDivideByZero.
+func Divide(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return divide("Divide", arrays, opts)
+}
+
 // DivideArrays divides multiple arrays element-wise and supports optional rounding to a specified precision.
+// Arrays holding a single element are broadcast against the others.
+// It is equivalent to Divide(arrays, WithPrecision(precision)).
 func DivideArrays(precision int, arrays ...[]float64) ([]float64, error) {
-	// Ensure at least two arrays are provided
-	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform division")
-	}
+	return divide("DivideArrays", arrays, []Option{WithPrecision(precision)})
+}
 
-	// Check that all arrays are the same length
-	length := len(arrays[0])
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+func divide(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-	// Initialize result to the first array
-	copy(result, arrays[0])
-	if arrays[0] == nil {
-		return nil, fmt.Errorf("the first array cannot be nil")
+	// Check that the arrays can be broadcast to a common length
+	length, err := checkOperands(op, arrays, true, "at least two arrays are required to perform division")
+	if err != nil {
+		return nil, err
 	}
-	if length == 0 {
-		return nil, fmt.Errorf("the first array cannot be empty")
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Loop through the remaining arrays and perform the division
-	for _, array := range arrays[1:] { // Start from the second array
-		for i := range array {
-			if array[i] == 0 {
-				return nil, fmt.Errorf("division by zero at index %d", i)
+	// Reject zero divisors before writing anything, so a failed call leaves WithOut untouched
+	for _, array := range arrays[1:] {
+		for i := 0; i < length; i++ {
+			if divisor := array[i%len(array)]; divisor == 0 {
+				return nil, valueError(op, ErrDivideByZero, i, divisor, "division by zero at index %d", i)
 			}
-			result[i] /= array[i]
 		}
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			fmt.Printf("Before Rounding: result[%d] = %f\n", i, result[i])
-			result[i] = math.Round(result[i]*factor) / factor
-			fmt.Printf("After Rounding: result[%d] = %f\n", i, result[i])
-		}
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	// Start from the first array and divide by the others, repeating single-element arrays
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			quotient := arrays[0][i%len(arrays[0])]
+			for _, array := range arrays[1:] {
+				quotient /= array[i%len(array)]
+			}
+			result[i] = quotient
+		}
+	})
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
 	return result, nil
 }
 
+// Power raises each element of base to the corresponding element of exponent. Either array may
+// hold a single element, which is broadcast against the other.
+func Power(base, exponent []float64, opts ...Option) ([]float64, error) {
+	return power("Power", base, exponent, opts)
+}
+
 // PowerArrays raises each element of the base array to the corresponding element of the exponent array and supports optional rounding to a specified precision.
+// Either array may hold a single element, which is broadcast against the other.
+// It is equivalent to Power(base, exponent, WithPrecision(precision)).
 func PowerArrays(precision int, base []float64, exponent []float64) ([]float64, error) {
-	// Ensure at least two arrays are provided
-	if len(base) == 0 || len(exponent) == 0 {
-		return nil, fmt.Errorf("both base and exponent arrays must be provided")
+	return power("PowerArrays", base, exponent, []Option{WithPrecision(precision)})
+}
+
+func power(op string, base, exponent []float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check that all arrays are the same length
-	if len(base) != len(exponent) {
-		return nil, fmt.Errorf("base and exponent arrays must be of the same length")
+	// Ensure both arrays are provided
+	if len(base) == 0 || len(exponent) == 0 {
+		return nil, emptyError(op, shapesOf(base, exponent), "both base and exponent arrays must be provided")
 	}
 
-	// Create a result slice initialized to zero
-	result := make([]float64, len(base))
+	// Check that the arrays can be broadcast to a common length
+	length, err := broadcastLength(op, base, exponent)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, base, exponent); err != nil {
+		return nil, err
+	}
 
-	// Loop through the arrays and perform the power operation
-	for i := range base {
-		result[i] = math.Pow(base[i], exponent[i])
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			fmt.Printf("Before Rounding: result[%d] = %f\n", i, result[i])
-			result[i] = math.Round(result[i]*factor) / factor
-			fmt.Printf("After Rounding: result[%d] = %f\n", i, result[i])
+	// Loop through the arrays and perform the power operation
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			result[i] = math.Pow(base[i%len(base)], exponent[i%len(exponent)])
 		}
-	}
+	})
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(base, exponent), result)
 
 	return result, nil
 }
 
+// Modulo calculates the remainder of dividend divided by divisor element-wise, as math.Mod does.
+// Either array may hold a single element, which is broadcast against the other.
+func Modulo(dividend, divisor []float64, opts ...Option) ([]float64, error) {
+	return modulo("Modulo", dividend, divisor, opts)
+}
+
 // ModuloArrays calculates the modulo of two arrays element-wise and supports optional rounding to a specified precision.
+// Either array may hold a single element, which is broadcast against the other.
+// It is equivalent to Modulo(dividend, divisor, WithPrecision(precision)).
 func ModuloArrays(precision int, dividend []float64, divisor []float64) ([]float64, error) {
-	// Ensure at least two arrays are provided
-	if len(dividend) == 0 || len(divisor) == 0 {
-		return nil, fmt.Errorf("both dividend and divisor arrays must be provided")
-	}
+	return modulo("ModuloArrays", dividend, divisor, []Option{WithPrecision(precision)})
+}
 
-	// Check that all arrays are the same length
-	if len(dividend) != len(divisor) {
-		return nil, fmt.Errorf("dividend and divisor arrays must be of the same length")
+func modulo(op string, dividend, divisor []float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a result slice initialized to zero
-	result := make([]float64, len(dividend))
+	// Ensure both arrays are provided
+	if len(dividend) == 0 || len(divisor) == 0 {
+		return nil, emptyError(op, shapesOf(dividend, divisor), "both dividend and divisor arrays must be provided")
+	}
 
-	// Loop through the arrays and perform the modulo operation
-	for i := range dividend {
-		if divisor[i] == 0 {
-			return nil, fmt.Errorf("division by zero at index %d", i)
-		}
-		result[i] = math.Mod(dividend[i], divisor[i])
+	// Check that the arrays can be broadcast to a common length
+	length, err := broadcastLength(op, dividend, divisor)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, dividend, divisor); err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			fmt.Printf("Before Rounding: result[%d] = %f\n", i, result[i])
-			result[i] = math.Round(result[i]*factor) / factor
-			fmt.Printf("After Rounding: result[%d] = %f\n", i, result[i])
+	// Reject zero divisors before writing anything
+	for i := 0; i < length; i++ {
+		if divisor[i%len(divisor)] == 0 {
+			return nil, valueError(op, ErrDivideByZero, i, 0, "division by zero at index %d", i)
 		}
 	}
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
+	// Loop through the arrays and perform the modulo operation
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			result[i] = math.Mod(dividend[i%len(dividend)], divisor[i%len(divisor)])
+		}
+	})
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(dividend, divisor), result)
+
 	return result, nil
 }
 
+// Log calculates the logarithm of each element of dividend in the corresponding base. Either
+// array may hold a single element, which is broadcast against the other. Non-positive values are
+// reported as ErrDomain.
+func Log(base, dividend []float64, opts ...Option) ([]float64, error) {
+	return logarithm("Log", base, dividend, opts)
+}
+
 // LogArrays calculates the logarithm of each element in the dividend array with respect to the base array and supports optional rounding to a specified precision.
+// Either array may hold a single element, which is broadcast against the other.
+// It is equivalent to Log(base, dividend, WithPrecision(precision)).
 func LogArrays(precision int, base []float64, dividend []float64) ([]float64, error) {
-	// Ensure at least two arrays are provided
-	if len(base) == 0 || len(dividend) == 0 {
-		return nil, fmt.Errorf("both base and dividend arrays must be provided")
-	}
+	return logarithm("LogArrays", base, dividend, []Option{WithPrecision(precision)})
+}
 
-	// Check that all arrays are the same length
-	if len(base) != len(dividend) {
-		return nil, fmt.Errorf("base and dividend arrays must be of the same length")
+func logarithm(op string, base, dividend []float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a result slice initialized to zero
-	result := make([]float64, len(base))
+	// Ensure both arrays are provided
+	if len(base) == 0 || len(dividend) == 0 {
+		return nil, emptyError(op, shapesOf(base, dividend), "both base and dividend arrays must be provided")
+	}
 
-	// Loop through the arrays and perform the logarithm operation
-	for i := range base {
-		if base[i] <= 0 || dividend[i] <= 0 {
-			return nil, fmt.Errorf("logarithm undefined for non-positive values at index %d", i)
-		}
-		result[i] = math.Log(dividend[i]) / math.Log(base[i])
+	// Check that the arrays can be broadcast to a common length
+	length, err := broadcastLength(op, base, dividend)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, base, dividend); err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			fmt.Printf("Before Rounding: result[%d] = %f\n", i, result[i])
-			result[i] = math.Round(result[i]*factor) / factor
-			fmt.Printf("After Rounding: result[%d] = %f\n", i, result[i])
+	// Reject non-positive values before writing anything
+	for i := 0; i < length; i++ {
+		b, d := base[i%len(base)], dividend[i%len(dividend)]
+		if b <= 0 || d <= 0 {
+			return nil, valueError(op, ErrDomain, i, min(b, d), "logarithm undefined for non-positive values at index %d", i)
 		}
 	}
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
+	// Loop through the arrays and perform the logarithm operation
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			result[i] = math.Log(dividend[i%len(dividend)]) / math.Log(base[i%len(base)])
+		}
+	})
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(base, dividend), result)
+
 	return result, nil
 }
 
+// Sqrt calculates the square root of the element-wise sum of arrays of equal length. A negative
+// sum is reported as ErrDomain.
+func Sqrt(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return sqrtSum("Sqrt", arrays, opts)
+}
+
 // SqrtArrays calculates the square root of the sum of multiple arrays element-wise and supports optional rounding to a specified precision.
+// It is equivalent to Sqrt(arrays, WithPrecision(precision)).
 func SqrtArrays(precision int, arrays ...[]float64) ([]float64, error) {
+	return sqrtSum("SqrtArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func sqrtSum(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure at least two arrays are provided
 	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform addition")
+		return nil, argumentError(op, "at least two arrays are required to perform addition")
 	}
 
 	// Check that all arrays are the same length
-	length := len(arrays[0])
-	if length == 0 {
-		return nil, fmt.Errorf("array cannot be empty")
-	}
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
 	}
-
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-
-	// Loop through the arrays and sum the elements element-wise
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
-		}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Check for negative values in the result
-	for i, value := range result {
-		if value < 0 {
-			return nil, fmt.Errorf("cannot calculate square root of a negative value at index %d: %f", i, value)
+	// Check for negative sums before writing anything
+	length := len(arrays[0])
+	for i := 0; i < length; i++ {
+		if value := sumAt(arrays, i); value < 0 {
+			return nil, valueError(op, ErrDomain, i, value, "cannot calculate square root of a negative value at index %d: %f", i, value)
 		}
 	}
 
-	// Take the square root of the summed result
-	for i := range result {
-		result[i] = math.Sqrt(result[i])
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			result[i] = math.Round(result[i]*factor) / factor
+	// Take the square root of the element-wise sum
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			result[i] = math.Sqrt(sumAt(arrays, i))
 		}
-	}
+	})
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
 	return result, nil
 }
 
+// Abs calculates the absolute value of the element-wise sum of arrays of equal length.
+func Abs(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return absSum("Abs", arrays, opts)
+}
+
 // AbsArrays calculates the absolute value of the sum of multiple arrays element-wise and supports optional rounding to a specified precision.
+// It is equivalent to Abs(arrays, WithPrecision(precision)).
 func AbsArrays(precision int, arrays ...[]float64) ([]float64, error) {
+	return absSum("AbsArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func absSum(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure at least two arrays are provided
 	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform addition")
+		return nil, argumentError(op, "at least two arrays are required to perform addition")
 	}
 
 	// Check that all arrays are the same length
-	length := len(arrays[0])
-	if length == 0 {
-		return nil, fmt.Errorf("array cannot be empty")
-	}
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
 	}
-
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-
-	// Loop through the arrays and sum the elements element-wise
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
-		}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Take the absolute value of the summed result
-	for i := range result {
-		result[i] = math.Abs(result[i])
+	length := len(arrays[0])
+	result, err := c.result(op, length)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			result[i] = math.Round(result[i]*factor) / factor
+	// Take the absolute value of the element-wise sum
+	c.parallel(length, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			result[i] = math.Abs(sumAt(arrays, i))
 		}
-	}
+	})
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
 	return result, nil
 }
 
+// Mean calculates the mean of arrays of equal length element-wise. Under NaNOmit each mean is
+// taken over the values that are not NaN. WithWeights weights each array, one weight per array.
+func Mean(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return mean("Mean", arrays, opts)
+}
+
 // MeanArrays calculates the mean of multiple arrays element-wise and supports optional rounding to a specified precision.
+// It is equivalent to Mean(arrays, WithPrecision(precision)).
 func MeanArrays(precision int, arrays ...[]float64) ([]float64, error) {
+	return mean("MeanArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func mean(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure at least two arrays are provided
 	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform addition")
+		return nil, argumentError(op, "at least two arrays are required to perform addition")
 	}
 
 	// Check that all arrays are the same length
-	length := len(arrays[0])
-	if length == 0 {
-		return nil, fmt.Errorf("array cannot be empty")
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
 	}
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
+	if c.weights != nil {
+		if err := checkWeights(op, c.weights, len(arrays)); err != nil {
+			return nil, err
 		}
 	}
-
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-
-	// Loop through the arrays and perform the mean operation
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
-		}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	for i := range result {
-		result[i] /= float64(len(arrays))
+	result, err := c.result(op, len(arrays[0]))
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			result[i] = math.Round(result[i]*factor) / factor
-		}
+	// Average the values at each position
+	if c.weights != nil {
+		c.positionwiseWeighted(result, arrays, c.weights, weightedLaneMean)
+	} else {
+		c.positionwise(result, arrays, laneMean)
 	}
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
 	return result, nil
 }
 
-// MedianArrays calculates the median of multiple arrays element-wise and supports optional rounding to a specified precision.
+// Median calculates the median of all the values in arrays of equal length taken together and
+// returns it as a single-element slice. The arrays are not modified. WithQuantileMethod selects
+// how the two middle values of an even count are combined; the default averages them.
+// WithWeights gives one weight per array to each of its values, for the weighted median.
+func Median(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return median("Median", arrays, opts)
+}
+
+// MedianArrays calculates the median of all the values in multiple arrays taken together, returned as a
+// single-element slice, and supports optional rounding to a specified precision.
+// It is equivalent to Median(arrays, WithPrecision(precision)).
 func MedianArrays(precision int, arrays ...[]float64) ([]float64, error) {
-	// Ensure at least two arrays are provided
-	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform addition")
-	}
+	return median("MedianArrays", arrays, []Option{WithPrecision(precision)})
+}
 
-	// Check that all arrays are the same length
-	length := len(arrays[0])
-	if length == 0 {
-		return nil, fmt.Errorf("array cannot be empty")
+func median(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+
+	// Ensure at least two arrays are provided
+	if len(arrays) < 2 {
+		return nil, argumentError(op, "at least two arrays are required to take their median")
 	}
 
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-
-	// Loop through the arrays and sum the elements element-wise
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
+	// Check that all arrays are the same length
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
+	}
+	if c.weights != nil {
+		if err := c.checkQuantileWeights(op, len(arrays)); err != nil {
+			return nil, err
 		}
 	}
-
-	// Divide each element by the number of arrays to calculate the median
-	for i := range result {
-		result[i] /= float64(len(arrays))
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Sort the result slice to find the median
-	sort.Float64s(result)
-	mid := len(result) / 2
+	result, err := c.result(op, 1)
+	if err != nil {
+		return nil, err
+	}
 
-	// Calculate the median
-	var median float64
-	if len(result)%2 == 0 {
-		median = (result[mid-1] + result[mid]) / 2
+	// Pool the values into a private copy that selection may reorder
+	pooled := make([]float64, 0, len(arrays)*len(arrays[0]))
+	for _, array := range arrays {
+		pooled = append(pooled, array...)
+	}
+	if c.weights != nil {
+		// Every value of an array carries the array's weight
+		weights := make([]float64, 0, len(pooled))
+		for i, array := range arrays {
+			for range array {
+				weights = append(weights, c.weights[i])
+			}
+		}
+		c.weightedQuantiles(result, pooled, weights, []float64{0.5})
 	} else {
-		median = result[mid]
+		result[0] = c.quantile(pooled, 0.5)
 	}
 
 	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		factor := math.Pow(10, float64(precision))
-		median = math.Round(median*factor) / factor
-	}
+	c.round(op, shapesOf(arrays...), result)
 
 	// Return the median as a single-element slice
-	return []float64{median}, nil
+	return result, nil
 }
 
 // ModeArray calculates the mode(s) of a slice of integers.
@@ -546,11 +628,30 @@ func ModeArray(nums []float64) []float64 {
 	return modes
 }
 
+// Mode calculates the mode(s) of the values pooled from all arrays. The modes are returned in no
+// particular order, so WithOut is not supported.
+func Mode(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return mode("Mode", arrays, opts)
+}
+
 // ModeMultipleArrays calculates the mode(s) across multiple arrays of integers.
+// It is equivalent to Mode(arrays, WithPrecision(precision)).
 func ModeMultipleArrays(precision int, arrays ...[]float64) ([]float64, error) {
+	return mode("ModeMultipleArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func mode(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.out != nil {
+		return nil, argumentError(op, "WithOut is not supported because the number of modes is not known in advance")
+	}
+
 	// Check if any arrays are provided
 	if len(arrays) == 0 {
-		return nil, fmt.Errorf("no arrays provided")
+		return nil, emptyError(op, shapesOf(arrays...), "no arrays provided")
 	}
 
 	// Check if all arrays are empty
@@ -562,13 +663,21 @@ func ModeMultipleArrays(precision int, arrays ...[]float64) ([]float64, error) {
 		}
 	}
 	if isAllEmpty {
-		return nil, fmt.Errorf("all arrays are empty")
+		return nil, emptyError(op, shapesOf(arrays...), "all arrays are empty")
+	}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
 	// Combine all arrays into a single slice
 	combined := []float64{}
 	for _, arr := range arrays {
-		combined = append(combined, arr...)
+		for _, num := range arr {
+			if c.omitNaN() && math.IsNaN(num) {
+				continue
+			}
+			combined = append(combined, num)
+		}
 	}
 
 	// Use a map to count occurrences of each number
@@ -590,377 +699,367 @@ func ModeMultipleArrays(precision int, arrays ...[]float64) ([]float64, error) {
 	}
 
 	// Apply rounding to the modes if precision is non-negative
-	if precision >= 0 {
-		factor := math.Pow(10, float64(precision))
-		for i := range modes {
-			modes[i] = math.Round(modes[i]*factor) / factor
-		}
-	}
-
-	// Validate precision range
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	c.round(op, shapesOf(arrays...), modes)
 
 	return modes, nil
 }
 
+// Variance calculates the variance of arrays of equal length element-wise. It is the population
+// variance unless WithDDOF sets the degrees of freedom: WithDDOF(1) gives the sample variance.
+// There must be more arrays than ddof; under NaNOmit positions left with too few values give NaN.
+// WithWeights weights each array, dividing by Σw − ddof for frequency weights and by
+// Σw − ddof·Σw²/Σw for reliability weights, as NumPy's cov does.
+func Variance(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return variance("Variance", arrays, opts)
+}
+
 // VarianceArrays calculates the variance of multiple arrays element-wise and supports optional rounding to a specified precision.
+// It is equivalent to Variance(arrays, WithPrecision(precision)).
 func VarianceArrays(precision int, arrays ...[]float64) ([]float64, error) {
+	return variance("VarianceArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func variance(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure at least two arrays are provided
 	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required")
+		return nil, argumentError(op, "at least two arrays are required")
 	}
 
 	// Check that all arrays are the same length
-	length := len(arrays[0])
-	if length == 0 {
-		return nil, fmt.Errorf("array cannot be empty")
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
 	}
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
+	if c.weights != nil {
+		if err := checkWeights(op, c.weights, len(arrays)); err != nil {
+			return nil, err
 		}
 	}
-
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-
-	// Loop through the arrays and sum the elements element-wise
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
-		}
+	if !(c.weightedDenominator(c.weights, len(arrays)) > 0) {
+		return nil, argumentError(op, "%d arrays leave no degrees of freedom with ddof %d", len(arrays), c.ddof)
 	}
-
-	// Calculate the mean for each element
-	for i := range result {
-		result[i] /= float64(len(arrays))
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Calculate the variance for each element
-	variance := make([]float64, length)
-	for _, array := range arrays {
-		for i := range array {
-			diff := array[i] - result[i]
-			variance[i] += diff * diff
-		}
+	result, err := c.result(op, len(arrays[0]))
+	if err != nil {
+		return nil, err
 	}
 
-	for i := range variance {
-		variance[i] /= float64(len(arrays))
+	// Calculate the variance of the values at each position
+	if c.weights != nil {
+		c.positionwiseWeighted(result, arrays, c.weights, c.weightedLaneVariance)
+	} else {
+		c.positionwise(result, arrays, func(values []float64) float64 {
+			return laneVariance(values, c.ddof)
+		})
 	}
 
-	if precision >= 0 {
-		for i := range variance {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			variance[i] = math.Round(variance[i]*factor) / factor
-		}
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	return result, nil
+}
 
-	return variance, nil
+// StandardDeviation calculates the standard deviation of arrays of equal length element-wise, as
+// the square root of Variance with the same options. Only the standard deviation is rounded, never
+// the variance it comes from.
+func StandardDeviation(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return standardDeviation("StandardDeviation", arrays, opts)
 }
 
 // StandardDeviationArrays calculates the standard deviation of multiple arrays element-wise and supports optional rounding to a specified precision.
+// It is equivalent to StandardDeviation(arrays, WithPrecision(precision)).
 func StandardDeviationArrays(precision int, arrays ...[]float64) ([]float64, error) {
-	// Calculate the variance using the VarianceArrays function
-	variance, err := VarianceArrays(precision, arrays...)
+	return standardDeviation("StandardDeviationArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func standardDeviation(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate the variance with the same options, but unrounded
+	stdDev, err := variance(op, arrays, append(opts[:len(opts):len(opts)], WithPrecision(-1)))
 	if err != nil {
 		return nil, err
 	}
 
 	// Take the square root of each variance value to calculate the standard deviation
-	stdDev := make([]float64, len(variance))
-	for i, v := range variance {
+	for i, v := range stdDev {
 		stdDev[i] = math.Sqrt(v)
 	}
 
 	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range stdDev {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			stdDev[i] = math.Round(stdDev[i]*factor) / factor
-		}
-	}
-
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	c.round(op, shapesOf(arrays...), stdDev)
 
 	return stdDev, nil
 }
 
+// Min finds the minimum value at each position across arrays of equal length.
+func Min(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return minimum("Min", arrays, opts)
+}
+
 // MinArrays finds the minimum value in each element across multiple arrays.
+// It is equivalent to Min(arrays, WithPrecision(precision)).
 func MinArrays(precision int, arrays ...[]float64) ([]float64, error) {
+	return minimum("MinArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func minimum(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure at least two arrays are provided
 	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform addition")
+		return nil, argumentError(op, "at least two arrays are required to perform addition")
 	}
 
 	// Check that all arrays are the same length
-	length := len(arrays[0])
-	if length == 0 {
-		return nil, fmt.Errorf("array cannot be empty")
-	}
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
 	}
-
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-
-	// Loop through the arrays and sum the elements element-wise
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
-		}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Find the minimum value in each element
-	minValues := make([]float64, length)
-	for i := range result {
-		minValues[i] = math.Inf(1)
+	result, err := c.result(op, len(arrays[0]))
+	if err != nil {
+		return nil, err
 	}
 
-	for _, array := range arrays {
-		for i := range array {
-			if array[i] < minValues[i] {
-				minValues[i] = array[i]
-			}
-		}
-	}
+	// Find the minimum value at each position
+	c.positionwise(result, arrays, func(values []float64) float64 {
+		return values[laneArgMin(values)]
+	})
 
-	if precision >= 0 {
-		for i := range minValues {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			minValues[i] = math.Round(minValues[i]*factor) / factor
-		}
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	return result, nil
+}
 
-	return minValues, nil
+// Max finds the maximum value at each position across arrays of equal length.
+func Max(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return maximum("Max", arrays, opts)
 }
 
 // MaxArrays finds the maximum value in each element across multiple arrays.
+// It is equivalent to Max(arrays, WithPrecision(precision)).
 func MaxArrays(precision int, arrays ...[]float64) ([]float64, error) {
+	return maximum("MaxArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func maximum(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure at least two arrays are provided
 	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform addition")
+		return nil, argumentError(op, "at least two arrays are required to perform addition")
 	}
 
 	// Check that all arrays are the same length
-	length := len(arrays[0])
-	if length == 0 {
-		return nil, fmt.Errorf("array cannot be empty")
-	}
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
 	}
-
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-
-	// Loop through the arrays and sum the elements element-wise
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
-		}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Find the maximum value in each element
-	maxValues := make([]float64, length)
-	for i := range result {
-		maxValues[i] = math.Inf(-1)
+	result, err := c.result(op, len(arrays[0]))
+	if err != nil {
+		return nil, err
 	}
 
-	for _, array := range arrays {
-		for i := range array {
-			if array[i] > maxValues[i] {
-				maxValues[i] = array[i]
-			}
-		}
-	}
+	// Find the maximum value at each position
+	c.positionwise(result, arrays, func(values []float64) float64 {
+		return values[laneArgMax(values)]
+	})
 
-	if precision >= 0 {
-		for i := range maxValues {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			maxValues[i] = math.Round(maxValues[i]*factor) / factor
-		}
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	return result, nil
+}
 
-	return maxValues, nil
+// PeakToPeak calculates the range (max - min) at each position across arrays of equal length.
+// It is named after NumPy's ptp because Range already names the slicing type.
+func PeakToPeak(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return peakToPeak("PeakToPeak", arrays, opts)
 }
 
 // RangeArrays calculates the range (max - min) of multiple arrays element-wise and supports optional rounding to a specified precision.
+// It is equivalent to PeakToPeak(arrays, WithPrecision(precision)).
 func RangeArrays(precision int, arrays ...[]float64) ([]float64, error) {
+	return peakToPeak("RangeArrays", arrays, []Option{WithPrecision(precision)})
+}
+
+func peakToPeak(op string, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ensure at least two arrays are provided
 	if len(arrays) < 2 {
-		return nil, fmt.Errorf("at least two arrays are required to perform addition")
+		return nil, argumentError(op, "at least two arrays are required to perform addition")
 	}
 
 	// Check that all arrays are the same length
-	length := len(arrays[0])
-	if length == 0 {
-		return nil, fmt.Errorf("array cannot be empty")
-	}
-	for _, array := range arrays {
-		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
-		}
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
 	}
-
-	// Create a result slice initialized to zero
-	result := make([]float64, length)
-
-	// Loop through the arrays and sum the elements element-wise
-	for _, array := range arrays {
-		for i := range array {
-			result[i] += array[i]
-		}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
 	}
 
-	// Find the minimum and maximum values in each element
-	minValues := make([]float64, length)
-	maxValues := make([]float64, length)
-	for i := range result {
-		minValues[i] = math.Inf(1)
-		maxValues[i] = math.Inf(-1)
+	result, err := c.result(op, len(arrays[0]))
+	if err != nil {
+		return nil, err
 	}
 
-	for _, array := range arrays {
-		for i := range array {
-			if array[i] < minValues[i] {
-				minValues[i] = array[i]
-			}
-			if array[i] > maxValues[i] {
-				maxValues[i] = array[i]
-			}
-		}
-	}
-	// Calculate the range (max - min)
-	rangeValues := make([]float64, length)
-	for i := range result {
-		rangeValues[i] = maxValues[i] - minValues[i]
-	}
+	// Calculate the range (max - min) at each position
+	c.positionwise(result, arrays, func(values []float64) float64 {
+		return values[laneArgMax(values)] - values[laneArgMin(values)]
+	})
 
-	if precision >= 0 {
-		for i := range rangeValues {
-			factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-			rangeValues[i] = math.Round(rangeValues[i]*factor) / factor
-		}
-	}
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
-	}
+	return result, nil
+}
 
-	return rangeValues, nil
+// Percentile calculates the given percentile of each array, returning one value per array. It
+// interpolates linearly unless WithQuantileMethod selects another estimator, and uses selection
+// rather than sorting, so the arrays are not modified. Under NaNOmit each percentile is taken over
+// the values that are not NaN. WithWeights weights the positions of every array, as Quantiles does.
+func Percentile(percentile float64, arrays [][]float64, opts ...Option) ([]float64, error) {
+	return percentileOf("Percentile", percentile, arrays, opts)
 }
 
 // PercentileArrays calculates the percentile of multiple arrays element-wise and supports optional rounding to a specified precision.
+// It is equivalent to Percentile(percentile, arrays, WithPrecision(precision)).
 func PercentileArrays(precision int, percentile float64, arrays ...[]float64) ([]float64, error) {
-	if percentile < 0 || percentile > 100 {
-		return nil, fmt.Errorf("percentile must be between 0 and 100")
+	return percentileOf("PercentileArrays", percentile, arrays, []Option{WithPrecision(precision)})
+}
+
+func percentileOf(op string, percentile float64, arrays [][]float64, opts []Option) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	if precision > 10 {
-		return nil, fmt.Errorf("precision too high; must be between -1 and 10")
+	if percentile < 0 || percentile > 100 {
+		return nil, argumentError(op, "percentile must be between 0 and 100")
 	}
 
 	// Check that all arrays are the same length
 	if len(arrays) == 0 {
-		return nil, fmt.Errorf("no arrays provided")
+		return nil, emptyError(op, shapesOf(arrays...), "no arrays provided")
 	}
 
 	length := len(arrays[0])
 	for _, array := range arrays {
 		if len(array) != length {
-			return nil, fmt.Errorf("all arrays must be of the same length")
+			return nil, shapeError(op, shapesOf(arrays...), "all arrays must be of the same length")
 		}
 	}
 
 	// Check that all arrays are non-empty
 	for i, arr := range arrays {
 		if len(arr) == 0 {
-			return nil, fmt.Errorf("array at index %d cannot be empty", i)
+			return nil, emptyError(op, shapesOf(arrays...), "array at index %d cannot be empty", i)
 		}
 	}
-
-	// Calculate the percentile for each array
-	results := make([]float64, len(arrays))
-	for i, arr := range arrays {
-		sort.Float64s(arr)
-		index := (percentile / 100) * float64(len(arr)-1)
-		intIndex := int(index)
-		fractionalPart := index - float64(intIndex)
-
-		var result float64
-		if intIndex+1 < len(arr) {
-			result = arr[intIndex] + fractionalPart*(arr[intIndex+1]-arr[intIndex])
-		} else {
-			result = arr[intIndex]
+	if c.weights != nil {
+		if err := c.checkQuantileWeights(op, length); err != nil {
+			return nil, err
 		}
+	}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
+	}
 
-		// Apply rounding if precision is non-negative
-		if precision >= 0 {
-			factor := math.Pow(10, float64(precision))
-			result = math.Round(result*factor) / factor
-		}
+	results, err := c.result(op, len(arrays))
+	if err != nil {
+		return nil, err
+	}
 
-		results[i] = result
+	// Calculate the percentile of a private copy of each array
+	for i, arr := range arrays {
+		if c.weights != nil {
+			c.weightedQuantiles(results[i:i+1], arr, c.weights, []float64{percentile / 100})
+			continue
+		}
+		results[i] = c.quantile(append([]float64(nil), arr...), percentile/100)
 	}
 
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), results)
+
 	return results, nil
 }
 
+// Transpose transposes a 2D matrix. With WithOut the rows of the result share the given buffer,
+// which must hold rows × columns elements.
+func Transpose(matrix [][]float64, opts ...Option) ([][]float64, error) {
+	return transpose("Transpose", matrix, opts)
+}
+
 // TransposeMatrix transposes a 2D matrix and supports optional rounding to a specified precision.
+// It is equivalent to Transpose(matrix, WithPrecision(precision)).
 func TransposeMatrix(precision int, matrix [][]float64) ([][]float64, error) {
-	// Validate precision
-	if precision < -1 || precision > 10 {
-		return nil, fmt.Errorf("precision must be between -1 and 10")
+	return transpose("TransposeMatrix", matrix, []Option{WithPrecision(precision)})
+}
+
+func transpose(op string, matrix [][]float64, opts []Option) ([][]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if the matrix is empty
 	if len(matrix) == 0 {
-		return nil, fmt.Errorf("matrix cannot be empty")
+		return nil, emptyError(op, matrixShapes(matrix), "matrix cannot be empty")
 	}
 
 	// Check if the matrix is nil
 	if matrix == nil {
-		return nil, fmt.Errorf("matrix cannot be nil")
+		return nil, emptyError(op, matrixShapes(matrix), "matrix cannot be nil")
 	}
 
 	// Check if the matrix is a valid 2D slice
 	width := len(matrix[0])
 	for _, row := range matrix {
 		if len(row) != width {
-			return nil, fmt.Errorf("all rows in the matrix must have the same length")
+			return nil, shapeError(op, matrixShapes(matrix), "all rows in the matrix must have the same length")
 		}
 	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return nil, err
+	}
 
 	// Get the dimensions of the matrix
 	length := len(matrix)
 
-	// Create a new matrix with transposed dimensions
-	result := make([][]float64, width)
-	for i := range result {
-		result[i] = make([]float64, length)
+	// Create a new matrix with transposed dimensions, backed by a single buffer
+	data, err := c.result(op, width*length)
+	if err != nil {
+		return nil, err
 	}
+	result := rowsOf(data, width, length)
 
 	// Fill the transposed matrix
 	for i := 0; i < length; i++ {
@@ -970,121 +1069,147 @@ func TransposeMatrix(precision int, matrix [][]float64) ([][]float64, error) {
 	}
 
 	// Apply rounding if precision is non-negative
-	if precision >= 0 {
-		for i := range result {
-			for j := range result[i] {
-				factor := math.Pow(10, float64(precision)) // e.g., 10^2 for two decimal places
-				result[i][j] = math.Round(result[i][j]*factor) / factor
-			}
-		}
-	}
+	c.round(op, [][]int{{length, width}}, data)
 
 	// Return the transposed matrix
 	return result, nil
 }
 
-// DeterminantMatrix calculates the determinant of a square matrix using recursion.
-func DeterminantMatrix(matrix [][]float64) (float64, error) {
-	// Check if the matrix is square
-	if len(matrix) == 0 || len(matrix) != len(matrix[0]) {
-		return 0, fmt.Errorf("matrix must be square and non-empty")
-	}
-
-	// Base case for 2x2 matrix
-	if len(matrix) == 2 {
-		return matrix[0][0]*matrix[1][1] - matrix[0][1]*matrix[1][0], nil
-	}
-
-	// Recursive case for larger matrices
-	det := 0.0
-	for i := range matrix {
-		subMatrix := make([][]float64, len(matrix)-1)
-		for j := range subMatrix {
-			subMatrix[j] = make([]float64, len(matrix)-1)
-			for k := range subMatrix[j] {
-				if k < i {
-					subMatrix[j][k] = matrix[j+1][k]
-				} else {
-					subMatrix[j][k] = matrix[j+1][k+1]
-				}
-			}
-		}
-		sign := 1.0
-		if i%2 != 0 {
-			sign = -1.0
-		}
-		subDet, err := DeterminantMatrix(subMatrix)
-		if err != nil {
-			return 0, err
-		}
-		det += sign * matrix[0][i] * subDet
+// checkOperands validates the operands of an element-wise function that folds arrays together and
+// returns their broadcast length. tooFew is the message reported for fewer than two arrays, and
+// requireFirst rejects a nil or empty first array, which seeds the fold.
+func checkOperands[T element](op string, arrays [][]T, requireFirst bool, tooFew string) (int, error) {
+	// Ensure at least two arrays are provided
+	if len(arrays) < 2 {
+		return 0, argumentError(op, "%s", tooFew)
 	}
 
-	return det, nil
+	length, err := broadcastLength(op, arrays...)
+	if err != nil {
+		return 0, err
+	}
+	if requireFirst && arrays[0] == nil {
+		return 0, emptyError(op, shapesOf(arrays...), "the first array cannot be nil")
+	}
+	if requireFirst && len(arrays[0]) == 0 {
+		return 0, emptyError(op, shapesOf(arrays...), "the first array cannot be empty")
+	}
+	return length, nil
 }
 
-// InversionMatrix calculates the inverse of a square matrix using Gaussian elimination.
-func InversionMatrix(matrix [][]float64) ([][]float64, error) {
-	// Check if the matrix is square
-	if len(matrix) == 0 || len(matrix) != len(matrix[0]) {
-		return nil, fmt.Errorf("matrix must be square and non-empty")
-	}
-
-	n := len(matrix)
-
-	// Create an augmented matrix [A|I] with A on the left and identity on the right
-	augmented := make([][]float64, n)
-	for i := range augmented {
-		augmented[i] = make([]float64, 2*n)
-		copy(augmented[i], matrix[i])
-		for j := 0; j < n; j++ {
-			if i == j {
-				augmented[i][j+n] = 1
-			} else {
-				augmented[i][j+n] = 0
-			}
+// sameLength checks that arrays are non-empty and all have the same length.
+func sameLength[T element](op string, arrays [][]T) error {
+	length := len(arrays[0])
+	if length == 0 {
+		return emptyError(op, shapesOf(arrays...), "array cannot be empty")
+	}
+	for _, array := range arrays {
+		if len(array) != length {
+			return shapeError(op, shapesOf(arrays...), "all arrays must be of the same length")
 		}
 	}
+	return nil
+}
+
+// sumAt sums the elements the arrays hold at position i.
+func sumAt(arrays [][]float64, i int) float64 {
+	sum := 0.0
+	for _, array := range arrays {
+		sum += array[i]
+	}
+	return sum
+}
 
-	// Apply Gaussian elimination to transform the left side to identity
-	for i := 0; i < n; i++ {
-		// Find the pivot element
-		pivot := augmented[i][i]
-		if pivot == 0 {
-			return nil, fmt.Errorf("matrix is singular and cannot be inverted")
+// positionwise sets dst[i] to fn of the values the arrays hold at position i, skipping NaN values
+// under NaNOmit. Positions left without values are set to NaN.
+func (c *config) positionwise(dst []float64, arrays [][]float64, fn func(values []float64) float64) {
+	c.parallel(len(dst), func(lo, hi int) {
+		values := make([]float64, 0, len(arrays))
+		for i := lo; i < hi; i++ {
+			values = values[:0]
+			for _, array := range arrays {
+				if c.omitNaN() && math.IsNaN(array[i]) {
+					continue
+				}
+				values = append(values, array[i])
+			}
+			if len(values) == 0 {
+				dst[i] = math.NaN()
+				continue
+			}
+			dst[i] = fn(values)
 		}
+	})
+}
 
-		// Normalize the pivot row
-		for j := 0; j < 2*n; j++ {
-			augmented[i][j] /= pivot
+// withoutNaN returns a copy of values with the NaN elements removed.
+func withoutNaN(values []float64) []float64 {
+	kept := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			kept = append(kept, v)
 		}
+	}
+	return kept
+}
 
-		// Eliminate the current column in other rows
-		for k := 0; k < n; k++ {
-			if k != i {
-				factor := augmented[k][i]
-				for j := 0; j < 2*n; j++ {
-					augmented[k][j] -= factor * augmented[i][j]
-				}
-			}
+// DeterminantMatrix calculates the determinant of a square matrix, including 1x1 matrices, from its
+// LU factorization with partial pivoting in O(n³) time. Every row must have as many entries as the
+// matrix has rows. The determinant of a large matrix can overflow to ±Inf or underflow to zero;
+// LogDeterminantMatrix avoids both.
+func DeterminantMatrix(matrix [][]float64) (float64, error) {
+	f, err := factorLU("DeterminantMatrix", matrix)
+	if err != nil {
+		return 0, err
+	}
+	return f.determinant(), nil
+}
+
+// LogDeterminantMatrix calculates the sign and the natural logarithm of the absolute value of the
+// determinant of a square matrix, so that det = sign · exp(logAbsDet), like NumPy's slogdet. The
+// sign is 1 or -1, or 0 with logAbsDet = -Inf for singular matrices. Working with logarithms keeps
+// the result finite where the determinant itself would overflow or underflow.
+func LogDeterminantMatrix(matrix [][]float64) (sign, logAbsDet float64, err error) {
+	f, err := factorLU("LogDeterminantMatrix", matrix)
+	if err != nil {
+		return 0, 0, err
+	}
+	if f.singular >= 0 {
+		return 0, math.Inf(-1), nil
+	}
+
+	// Sum the logarithms of the pivots, tracking their signs separately
+	sign = f.sign
+	for i := range f.lu {
+		pivot := f.lu[i][i]
+		if pivot < 0 {
+			sign = -sign
 		}
+		logAbsDet += math.Log(math.Abs(pivot))
 	}
+	return sign, logAbsDet, nil
+}
 
-	// Extract the inverse matrix from the augmented matrix
-	inverse := make([][]float64, n)
-	for i := range inverse {
-		inverse[i] = make([]float64, n)
-		copy(inverse[i], augmented[i][n:])
+// InversionMatrix calculates the inverse of a square matrix by solving for each column of the
+// identity with an LU factorization with partial pivoting.
+func InversionMatrix(matrix [][]float64) ([][]float64, error) {
+	f, err := factorLU("InversionMatrix", matrix)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.checkSingular("InversionMatrix", "matrix is singular and cannot be inverted"); err != nil {
+		return nil, err
 	}
 
-	return inverse, nil
+	return f.inverse(), nil
 }
 
-// Eigenvalues2x2 computes the eigenvalues of a 2x2 matrix.
+// Eigenvalues2x2 computes the eigenvalues of a 2x2 matrix. It reports complex eigenvalues as an
+// error; Eigen returns them.
 func Eigenvalues2x2(matrix [][]float64) ([]float64, error) {
 	// Check if the matrix is 2x2
 	if len(matrix) != 2 || len(matrix[0]) != 2 || len(matrix[1]) != 2 {
-		return nil, fmt.Errorf("matrix must be 2x2")
+		return nil, shapeError("Eigenvalues2x2", matrixShapes(matrix), "matrix must be 2x2")
 	}
 
 	a := matrix[0][0]
@@ -1099,7 +1224,7 @@ func Eigenvalues2x2(matrix [][]float64) ([]float64, error) {
 	// Calculate the eigenvalues using the quadratic formula
 	discriminant := trace*trace - 4*det
 	if discriminant < 0 {
-		return nil, fmt.Errorf("complex eigenvalues")
+		return nil, valueError("Eigenvalues2x2", ErrDomain, -1, discriminant, "complex eigenvalues")
 	}
 
 	eigenvalue1 := (trace + math.Sqrt(discriminant)) / 2
@@ -1109,32 +1234,11 @@ func Eigenvalues2x2(matrix [][]float64) ([]float64, error) {
 }
 
 // Eigenvalues3x3AndHigher computes the eigenvalues of a square matrix of size 3x3 or larger.
+// It is equivalent to Eigen(matrix, EigenNone).Values and works for any size.
 func Eigenvalues3x3AndHigher(matrix [][]float64) ([]complex128, error) {
-	// Check if the matrix is square
-	if len(matrix) == 0 || len(matrix) != len(matrix[0]) {
-		return nil, fmt.Errorf("matrix must be square and non-empty")
-	}
-
-	n := len(matrix)
-
-	// Convert the input matrix to a Gonum Dense matrix
-	data := make([]float64, n*n)
-	for i := 0; i < n; i++ {
-		for j := 0; j < n; j++ {
-			data[i*n+j] = matrix[i][j]
-		}
-	}
-	gonumMatrix := mat.NewDense(n, n, data)
-
-	// Compute the eigenvalues using Gonum's Eigen method
-	var eig mat.Eigen
-	ok := eig.Factorize(gonumMatrix, mat.EigenNone)
-	if !ok {
-		return nil, fmt.Errorf("failed to compute eigenvalues")
+	result, err := eigen("Eigenvalues3x3AndHigher", matrix, EigenNone, nil)
+	if err != nil {
+		return nil, err
 	}
-
-	// Extract the eigenvalues
-	eigenvalues := eig.Values(nil)
-
-	return eigenvalues, nil
+	return result.Values, nil
 }
@@ -1,6 +1,8 @@
 package litearray
 
 import (
+	"errors"
+	"math"
 	"math/cmplx"
 	"testing"
 )
@@ -35,7 +37,7 @@ func TestAddArrays(t *testing.T) {
 	}
 
 	// Test case 3: Mismatched array lengths
-	arr3 := []float64{1.0}
+	arr3 := []float64{1.0, 2.0}
 	_, err = AddArrays(2, arr1, arr3)
 	if err == nil {
 		t.Error("Expected an error for mismatched array lengths, got none")
@@ -46,6 +48,15 @@ func TestAddArrays(t *testing.T) {
 	if err == nil {
 		t.Error("Expected an error for fewer than two arrays, got none")
 	}
+
+	// Test case 5: Single-element arrays are broadcast
+	result, err = AddArrays(2, []float64{1, 2, 3}, []float64{10})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result, []float64{11, 12, 13}, 0.0001) {
+		t.Errorf("Expected %v, got %v", []float64{11, 12, 13}, result)
+	}
 }
 
 func TestSubtractArrays(t *testing.T) {
@@ -78,7 +89,7 @@ func TestSubtractArrays(t *testing.T) {
 	}
 
 	// Test case 3: Mismatched array lengths
-	arr3 := []float64{1.0}
+	arr3 := []float64{1.0, 2.0}
 	_, err = SubtractArrays(2, arr1, arr3)
 	if err == nil {
 		t.Error("Expected an error for mismatched array lengths, got none")
@@ -89,6 +100,15 @@ func TestSubtractArrays(t *testing.T) {
 	if err == nil {
 		t.Error("Expected an error for fewer than two arrays, got none")
 	}
+
+	// Test case 5: Single-element arrays are broadcast
+	result, err = SubtractArrays(2, []float64{10}, []float64{1, 2, 3})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result, []float64{9, 8, 7}, 0.0001) {
+		t.Errorf("Expected %v, got %v", []float64{9, 8, 7}, result)
+	}
 }
 
 func TestMultiplyArrays(t *testing.T) {
@@ -121,7 +141,7 @@ func TestMultiplyArrays(t *testing.T) {
 	}
 
 	// Test case 3: Mismatched array lengths
-	arr3 := []float64{1.0}
+	arr3 := []float64{1.0, 2.0}
 	_, err = MultiplyArrays(2, arr1, arr3)
 	if err == nil {
 		t.Error("Expected an error for mismatched array lengths, got none")
@@ -132,6 +152,15 @@ func TestMultiplyArrays(t *testing.T) {
 	if err == nil {
 		t.Error("Expected an error for fewer than two arrays, got none")
 	}
+
+	// Test case 5: Single-element arrays are broadcast
+	result, err = MultiplyArrays(2, []float64{1, 2, 3}, []float64{2})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result, []float64{2, 4, 6}, 0.0001) {
+		t.Errorf("Expected %v, got %v", []float64{2, 4, 6}, result)
+	}
 }
 
 func TestDivideArrays(t *testing.T) {
@@ -164,7 +193,7 @@ func TestDivideArrays(t *testing.T) {
 	}
 
 	// Test case 3: Mismatched array lengths
-	arr3 := []float64{1.0}
+	arr3 := []float64{1.0, 2.0}
 	_, err = DivideArrays(2, arr1, arr3)
 	if err == nil {
 		t.Error("Expected an error for mismatched array lengths, got none")
@@ -175,6 +204,15 @@ func TestDivideArrays(t *testing.T) {
 	if err == nil {
 		t.Error("Expected an error for fewer than two arrays, got none")
 	}
+
+	// Test case 5: Single-element arrays are broadcast
+	result, err = DivideArrays(2, []float64{2, 4, 6}, []float64{2})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result, []float64{1, 2, 3}, 0.0001) {
+		t.Errorf("Expected %v, got %v", []float64{1, 2, 3}, result)
+	}
 }
 
 func TestPowerArrays(t *testing.T) {
@@ -207,7 +245,7 @@ func TestPowerArrays(t *testing.T) {
 	}
 
 	// Test case 3: Mismatched array lengths
-	arr3 := []float64{1.0}
+	arr3 := []float64{1.0, 2.0}
 	_, err = PowerArrays(2, arr1, arr3)
 	if err == nil {
 		t.Error("Expected an error for mismatched array lengths, got none")
@@ -218,6 +256,15 @@ func TestPowerArrays(t *testing.T) {
 	if err == nil {
 		t.Error("Expected an error for fewer than two arrays, got none")
 	}
+
+	// Test case 5: Single-element arrays are broadcast
+	result, err = PowerArrays(2, []float64{1, 2, 3}, []float64{2})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result, []float64{1, 4, 9}, 0.0001) {
+		t.Errorf("Expected %v, got %v", []float64{1, 4, 9}, result)
+	}
 }
 
 func TestModuloArrays(t *testing.T) {
@@ -250,7 +297,7 @@ func TestModuloArrays(t *testing.T) {
 	}
 
 	// Test case 3: Mismatched array lengths
-	arr3 := []float64{1.0}
+	arr3 := []float64{1.0, 2.0}
 	_, err = ModuloArrays(2, arr1, arr3)
 	if err == nil {
 		t.Error("Expected an error for mismatched array lengths, got none")
@@ -261,6 +308,15 @@ func TestModuloArrays(t *testing.T) {
 	if err == nil {
 		t.Error("Expected an error for fewer than two arrays, got none")
 	}
+
+	// Test case 5: Single-element arrays are broadcast
+	result, err = ModuloArrays(2, []float64{10}, []float64{3, 4, 6})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result, []float64{1, 2, 4}, 0.0001) {
+		t.Errorf("Expected %v, got %v", []float64{1, 2, 4}, result)
+	}
 }
 
 func TestLogArrays(t *testing.T) {
@@ -293,7 +349,7 @@ func TestLogArrays(t *testing.T) {
 	}
 
 	// Test case 3: Mismatched array lengths
-	arr3 := []float64{1.0}
+	arr3 := []float64{1.0, 2.0}
 	_, err = LogArrays(2, arr1, arr3)
 	if err == nil {
 		t.Error("Expected an error for mismatched array lengths, got none")
@@ -304,6 +360,15 @@ func TestLogArrays(t *testing.T) {
 	if err == nil {
 		t.Error("Expected an error for fewer than two arrays, got none")
 	}
+
+	// Test case 5: Single-element arrays are broadcast
+	result, err = LogArrays(2, []float64{2}, []float64{2, 4, 8})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result, []float64{1, 2, 3}, 0.0001) {
+		t.Errorf("Expected %v, got %v", []float64{1, 2, 3}, result)
+	}
 }
 
 func TestSqrtArrays(t *testing.T) {
@@ -602,6 +667,38 @@ func TestStandardDeviationArrays(t *testing.T) {
 	}
 }
 
+func TestVarianceDDOFAndStability(t *testing.T) {
+	arrays := [][]float64{{1e9 + 4, 4}, {1e9 + 7, 7}, {1e9 + 13, 13}, {1e9 + 16, 16}}
+
+	// Test case 1: Population and sample variance, accurate for large offsets
+	population, err := Variance(arrays)
+	if err != nil || !compareSlices(population, []float64{22.5, 22.5}, 0) {
+		t.Errorf("Expected [22.5 22.5], got %v (err %v)", population, err)
+	}
+	sample, err := Variance(arrays, WithDDOF(1))
+	if err != nil || !compareSlices(sample, []float64{30, 30}, 0) {
+		t.Errorf("Expected [30 30], got %v (err %v)", sample, err)
+	}
+
+	// Test case 2: The standard deviation is the root of the unrounded variance
+	stdDev, err := StandardDeviationArrays(2, []float64{0}, []float64{0.1})
+	if err != nil || !compareSlices(stdDev, []float64{0.05}, 0) {
+		t.Errorf("Expected [0.05], got %v (err %v)", stdDev, err)
+	}
+
+	// Test case 3: Too few values for the degrees of freedom
+	result, _ := Variance([][]float64{{1, 2}, {math.NaN(), 4}}, WithDDOF(1), WithNaNPolicy(NaNOmit))
+	if !math.IsNaN(result[0]) || result[1] != 2 {
+		t.Errorf("Expected [NaN 2], got %v", result)
+	}
+	if _, err := Variance(arrays[:2], WithDDOF(2)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := Variance(arrays, WithDDOF(-1)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
 func TestMinArrays(t *testing.T) {
 	// Test case 1: Regular array, precision = 2
 	arrays := [][]float64{{1.00000, 2.00000, 3.00000}, {4.00000, 5.00000, 6.00000}, {7.00000, 8.00000, 9.00000}}
@@ -823,7 +920,7 @@ func TestDeterminantMatrix(t *testing.T) {
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
-	if result != expected {
+	if math.Abs(result-expected) > 1e-12 {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 
@@ -835,6 +932,66 @@ func TestDeterminantMatrix(t *testing.T) {
 	}
 }
 
+func TestDeterminantMatrixShapes(t *testing.T) {
+	// Test case 1: 1x1 matrix
+	result, err := DeterminantMatrix([][]float64{{-4}})
+	if err != nil || result != -4 {
+		t.Errorf("Expected -4, got %v (err %v)", result, err)
+	}
+
+	// Test case 2: Ragged rows are rejected
+	_, err = DeterminantMatrix([][]float64{{1, 2, 3}, {4, 5}, {6, 7, 8}})
+	if err == nil || err.Error() != "matrix must be square and non-empty" {
+		t.Errorf("Expected error 'matrix must be square and non-empty', got %v", err)
+	}
+
+	// Test case 3: Large matrices finish quickly
+	n := 120
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		matrix[i][i] = 1.5
+		matrix[i][(i+1)%n] = 0.25
+	}
+	result, err = DeterminantMatrix(matrix)
+	if err != nil || math.Abs(result/math.Pow(1.5, float64(n))-1) > 1e-9 {
+		t.Errorf("Expected about %v, got %v (err %v)", math.Pow(1.5, float64(n)), result, err)
+	}
+}
+
+func TestLogDeterminantMatrix(t *testing.T) {
+	// Test case 1: Matches the determinant of a small matrix
+	sign, logDet, err := LogDeterminantMatrix([][]float64{{2, 3, 1}, {4, 5, 6}, {7, 8, 9}})
+	if err != nil || sign != 1 || math.Abs(logDet-math.Log(9)) > 1e-12 {
+		t.Errorf("Expected sign 1 and log 9, got %v and %v (err %v)", sign, logDet, err)
+	}
+
+	// Test case 2: Negative determinants
+	sign, logDet, err = LogDeterminantMatrix([][]float64{{0, 2}, {3, 0}})
+	if err != nil || sign != -1 || math.Abs(logDet-math.Log(6)) > 1e-12 {
+		t.Errorf("Expected sign -1 and log 6, got %v and %v (err %v)", sign, logDet, err)
+	}
+
+	// Test case 3: Determinants beyond the float64 range
+	n := 400
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		matrix[i][i] = -10
+	}
+	det, _ := DeterminantMatrix(matrix)
+	sign, logDet, err = LogDeterminantMatrix(matrix)
+	if !math.IsInf(det, 1) || err != nil || sign != 1 || math.Abs(logDet-float64(n)*math.Log(10)) > 1e-9 {
+		t.Errorf("Expected sign 1 and log %v, got %v and %v (err %v)", float64(n)*math.Log(10), sign, logDet, err)
+	}
+
+	// Test case 4: Singular matrices
+	sign, logDet, err = LogDeterminantMatrix([][]float64{{1, 2}, {2, 4}})
+	if err != nil || sign != 0 || !math.IsInf(logDet, -1) {
+		t.Errorf("Expected sign 0 and -Inf, got %v and %v (err %v)", sign, logDet, err)
+	}
+}
+
 func TestInversionMatrix(t *testing.T) {
 	// Test case 1: Regular matrix
 	matrix := [][]float64{{2, 3}, {4, 5}}
@@ -903,12 +1060,20 @@ func TestEigenvalues3x3AndHigher(t *testing.T) {
 	}
 }
 
-// compareSlices checks if two float64 slices are equal within a given tolerance.
+// compareSlices checks if two float64 slices are equal within a given tolerance, treating NaN as
+// equal to NaN and to nothing else.
 func compareSlices(a, b []float64, tolerance float64) bool {
 	if len(a) != len(b) {
 		return false
 	}
 	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+			// NaN matches only NaN
+			if math.IsNaN(a[i]) != math.IsNaN(b[i]) {
+				return false
+			}
+			continue
+		}
 		if diff := a[i] - b[i]; diff < -tolerance || diff > tolerance {
 			return false
 		}
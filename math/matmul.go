@@ -0,0 +1,242 @@
+package litearray
+
+import "gonum.org/v1/gonum/floats"
+
+// MatMul calculates the matrix product A·B of an m×k and a k×n matrix. Unlike MultiplyArrays,
+// which multiplies element by element, each entry of the result is the dot product of a row of A
+// and a column of B. Large products use a cache-blocked kernel that is split across goroutines
+// with WithParallelism; at 1000×1000 it runs at about the speed of Gonum's Dense.Mul on a single
+// core. With WithOut the rows of the result share the given buffer, which must hold m × n
+// elements and may overlap the inputs.
+func MatMul(a, b [][]float64, opts ...Option) ([][]float64, error) {
+	const op = "MatMul"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := checkRectangular(op, a)
+	if err != nil {
+		return nil, err
+	}
+	n, err := checkRectangular(op, b)
+	if err != nil {
+		return nil, err
+	}
+	m := len(a)
+	if len(b) != k {
+		return nil, shapeError(op, [][]int{{m, k}, {len(b), n}}, "matrices of shapes %dx%d and %dx%d cannot be multiplied: inner dimensions %d and %d differ", m, k, len(b), n, k, len(b))
+	}
+	if err := c.checkNaN(op, a...); err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, b...); err != nil {
+		return nil, err
+	}
+
+	// The kernel accumulates into its output, so work in a fresh buffer in case WithOut overlaps
+	// an input
+	data, err := c.result(op, m*n)
+	if err != nil {
+		return nil, err
+	}
+	product := make([]float64, m*n)
+	c.gemm(m, n, k, flatten(a, k), flatten(b, n), product)
+	copy(data, product)
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{m, k}, {k, n}}, data)
+
+	return rowsOf(data, m, n), nil
+}
+
+// MatVec calculates the product A·x of an m×n matrix and a vector of length n.
+func MatVec(a [][]float64, x []float64, opts ...Option) ([]float64, error) {
+	const op = "MatVec"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := checkRectangular(op, a)
+	if err != nil {
+		return nil, err
+	}
+	if len(x) != n {
+		return nil, shapeError(op, [][]int{{len(a), n}, {len(x)}}, "vector has length %d, expected %d", len(x), n)
+	}
+	if err := c.checkNaN(op, a...); err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, x); err != nil {
+		return nil, err
+	}
+
+	// Compute into a fresh slice in case WithOut overlaps x
+	product := make([]float64, len(a))
+	c.parallelChunks(len(a), max(1, minChunk/max(n, 1)), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			product[i] = floats.Dot(a[i], x)
+		}
+	})
+	result, err := c.result(op, len(a))
+	if err != nil {
+		return nil, err
+	}
+	copy(result, product)
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{len(a), n}, {n}}, result)
+
+	return result, nil
+}
+
+// Dot calculates the dot product of two vectors of the same length. The dot product of two empty
+// vectors is 0.
+func Dot(x, y []float64, opts ...Option) (float64, error) {
+	const op = "Dot"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return 0, err
+	}
+	if len(x) != len(y) {
+		return 0, shapeError(op, shapesOf(x, y), "vectors have lengths %d and %d", len(x), len(y))
+	}
+	if err := c.checkNaN(op, x, y); err != nil {
+		return 0, err
+	}
+
+	result := []float64{floats.Dot(x, y)}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(x, y), result)
+
+	return result[0], nil
+}
+
+// Outer calculates the outer product x·yᵀ of two vectors, an m×n matrix whose entry (i, j) is
+// x[i]·y[j]. With WithOut the rows of the result share the given buffer.
+func Outer(x, y []float64, opts ...Option) ([][]float64, error) {
+	const op = "Outer"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(x) == 0 || len(y) == 0 {
+		return nil, emptyError(op, shapesOf(x, y), "vectors cannot be empty")
+	}
+	if err := c.checkNaN(op, x, y); err != nil {
+		return nil, err
+	}
+
+	m, n := len(x), len(y)
+	product := make([]float64, m*n)
+	for i, v := range x {
+		floats.ScaleTo(product[i*n:(i+1)*n], v, y)
+	}
+	data, err := c.result(op, m*n)
+	if err != nil {
+		return nil, err
+	}
+	copy(data, product)
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(x, y), data)
+
+	return rowsOf(data, m, n), nil
+}
+
+// MatMul calculates the matrix product of two arrays following NumPy's matmul rules. Arrays with
+// two dimensions are multiplied as matrices. Arrays with more dimensions are stacks of matrices
+// held in the last two axes, and the leading batch axes are broadcast against each other, so a
+// single matrix can be applied to every matrix of a stack. A one-dimensional operand is treated
+// as a row vector on the left or a column vector on the right, and that axis is removed from the
+// result.
+func (a *Array) MatMul(b *Array, opts ...Option) (*Array, error) {
+	const op = "Array.MatMul"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil || b == nil {
+		return nil, emptyError(op, nil, "arrays cannot be nil")
+	}
+	if len(a.shape) == 0 || len(b.shape) == 0 {
+		return nil, shapeError(op, [][]int{a.shape, b.shape}, "matmul operands must have at least one dimension")
+	}
+
+	// Promote vectors to matrices, remembering which axes to drop again
+	left, right := a.shape, b.shape
+	if len(left) == 1 {
+		left = []int{1, left[0]}
+	}
+	if len(right) == 1 {
+		right = []int{right[0], 1}
+	}
+	m, k, n := left[len(left)-2], left[len(left)-1], right[len(right)-1]
+	if right[len(right)-2] != k {
+		return nil, shapeError(op, [][]int{a.shape, b.shape}, "arrays of shapes %v and %v cannot be multiplied: inner dimensions %d and %d differ", a.shape, b.shape, k, right[len(right)-2])
+	}
+
+	// Broadcast the batch axes
+	batch, err := BroadcastShapes(left[:len(left)-2], right[:len(right)-2])
+	if err != nil {
+		return nil, err
+	}
+	leftView, err := a.Reshape(left...)
+	if err != nil {
+		return nil, err
+	}
+	rightView, err := b.Reshape(right...)
+	if err != nil {
+		return nil, err
+	}
+	leftView, err = leftView.BroadcastTo(append(append([]int{}, batch...), m, k)...)
+	if err != nil {
+		return nil, err
+	}
+	rightView, err = rightView.BroadcastTo(append(append([]int{}, batch...), k, n)...)
+	if err != nil {
+		return nil, err
+	}
+	leftData, rightData := leftView.Data(), rightView.Data()
+	if err := c.checkNaN(op, leftData, rightData); err != nil {
+		return nil, err
+	}
+
+	// Multiply one pair of matrices at a time
+	count := 1
+	for _, dim := range batch {
+		count *= dim
+	}
+	product := make([]float64, count*m*n)
+	for i := 0; i < count; i++ {
+		c.gemm(m, n, k, leftData[i*m*k:(i+1)*m*k], rightData[i*k*n:(i+1)*k*n], product[i*m*n:(i+1)*m*n])
+	}
+	data, err := c.result(op, len(product))
+	if err != nil {
+		return nil, err
+	}
+	copy(data, product)
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{a.shape, b.shape}, data)
+
+	shape := append([]int{}, batch...)
+	if len(a.shape) > 1 {
+		shape = append(shape, m)
+	}
+	if len(b.shape) > 1 {
+		shape = append(shape, n)
+	}
+	return wrapArray(data, shape), nil
+}
+
+// flatten copies the rows of a matrix with the given number of columns into a row-major buffer.
+func flatten(matrix [][]float64, cols int) []float64 {
+	data := make([]float64, len(matrix)*cols)
+	for i, row := range matrix {
+		copy(data[i*cols:], row)
+	}
+	return data
+}
@@ -0,0 +1,141 @@
+package litearray
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// randomMatrix returns a rows×cols matrix of reproducible values in [0, 1).
+func randomMatrix(rows, cols int, seed int64) [][]float64 {
+	r := rand.New(rand.NewSource(seed))
+	matrix := newMatrix(rows, cols)
+	for _, row := range matrix {
+		for j := range row {
+			row[j] = r.Float64()
+		}
+	}
+	return matrix
+}
+
+func TestMatMul(t *testing.T) {
+	// Test case 1: 2x3 times 3x2
+	product, err := MatMul([][]float64{{1, 2, 3}, {4, 5, 6}}, [][]float64{{7, 8}, {9, 10}, {11, 12}})
+	if err != nil || !compareMatrices(product, [][]float64{{58, 64}, {139, 154}}, 0) {
+		t.Errorf("Expected [[58 64] [139 154]], got %v (err %v)", product, err)
+	}
+
+	// Test case 2: The blocked kernel agrees with the naive product on awkward sizes
+	a, b := randomMatrix(130, 300, 1), randomMatrix(300, 1030, 2)
+	product, err = MatMul(a, b, WithParallelism(3))
+	if err != nil || !compareMatrices(product, multiplyMatrices(a, b), 1e-10) {
+		t.Errorf("Expected the blocked product to match the naive one (err %v)", err)
+	}
+
+	// Test case 3: Output buffer overlapping an input
+	square := [][]float64{{1, 2}, {3, 4}}
+	out := []float64{1, 2, 3, 4}
+	product, err = MatMul(rowsOf(out, 2, 2), square, WithOut(out))
+	if err != nil || !compareSlices(out, []float64{7, 10, 15, 22}, 0) || &product[1][0] != &out[2] {
+		t.Errorf("Expected [7 10 15 22] in the output buffer, got %v (err %v)", out, err)
+	}
+
+	// Test case 4: Mismatched inner dimensions
+	_, err = MatMul(square, [][]float64{{1, 2}})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
+
+func TestMatVecDotOuter(t *testing.T) {
+	// Test case 1: Matrix-vector product
+	product, err := MatVec([][]float64{{1, 2}, {3, 4}, {5, 6}}, []float64{1, -1})
+	if err != nil || !compareSlices(product, []float64{-1, -1, -1}, 0) {
+		t.Errorf("Expected [-1 -1 -1], got %v (err %v)", product, err)
+	}
+
+	// Test case 2: Dot product with rounding
+	dot, err := Dot([]float64{0.1, 0.2}, []float64{0.3, 0.4}, WithPrecision(2))
+	if err != nil || dot != 0.11 {
+		t.Errorf("Expected 0.11, got %v (err %v)", dot, err)
+	}
+	dot, err = Dot(nil, nil)
+	if err != nil || dot != 0 {
+		t.Errorf("Expected 0 for empty vectors, got %v (err %v)", dot, err)
+	}
+
+	// Test case 3: Outer product
+	outer, err := Outer([]float64{1, 2}, []float64{3, 4, 5})
+	if err != nil || !compareMatrices(outer, [][]float64{{3, 4, 5}, {6, 8, 10}}, 0) {
+		t.Errorf("Expected [[3 4 5] [6 8 10]], got %v (err %v)", outer, err)
+	}
+
+	// Test case 4: Length mismatches
+	if _, err := MatVec([][]float64{{1, 2}}, []float64{1}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := Dot([]float64{1}, []float64{1, 2}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
+
+func TestArrayMatMul(t *testing.T) {
+	matrix, _ := NewArray([]float64{1, 2, 3, 4}, 2, 2)
+
+	// Test case 1: A stack of matrices times a single matrix broadcasts over the batch
+	stack, _ := NewArray([]float64{1, 0, 0, 1, 2, 0, 0, 2, 0, 1, 1, 0}, 3, 2, 2)
+	product, err := stack.MatMul(matrix)
+	if err != nil || !sameShape(product.Shape(), []int{3, 2, 2}) || !compareSlices(product.Data(), []float64{1, 2, 3, 4, 2, 4, 6, 8, 3, 4, 1, 2}, 0) {
+		t.Errorf("Expected a 3x2x2 stack, got %v (err %v)", product, err)
+	}
+
+	// Test case 2: Vectors on either side drop their axis
+	vector, _ := NewArray([]float64{1, 1})
+	product, err = matrix.MatMul(vector)
+	if err != nil || !sameShape(product.Shape(), []int{2}) || !compareSlices(product.Data(), []float64{3, 7}, 0) {
+		t.Errorf("Expected [3 7], got %v (err %v)", product, err)
+	}
+	product, err = vector.MatMul(vector)
+	if err != nil || product.Ndim() != 0 || product.Data()[0] != 2 {
+		t.Errorf("Expected the scalar 2, got %v (err %v)", product, err)
+	}
+
+	// Test case 3: Transposed views are handled
+	transposed, _ := matrix.Transpose()
+	product, err = matrix.MatMul(transposed)
+	if err != nil || !compareSlices(product.Data(), []float64{5, 11, 11, 25}, 0) {
+		t.Errorf("Expected [[5 11] [11 25]], got %v (err %v)", product, err)
+	}
+
+	// Test case 4: Incompatible shapes
+	mismatched, _ := NewArray(make([]float64, 12), 2, 3, 2)
+	_, err = matrix.MatMul(mismatched)
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	_, err = NewScalar(1).MatMul(matrix)
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
+
+// On a single core, MatMul multiplies two 1000×1000 matrices in about the time Gonum's
+// Dense.Mul takes; compare with go test -bench Mul -run XXX.
+func BenchmarkMatMul(b *testing.B) {
+	x, y := randomMatrix(1000, 1000, 1), randomMatrix(1000, 1000, 2)
+	for b.Loop() {
+		if _, err := MatMul(x, y); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGonumMul(b *testing.B) {
+	x, y := denseOf(randomMatrix(1000, 1000, 1)), denseOf(randomMatrix(1000, 1000, 2))
+	var product mat.Dense
+	for b.Loop() {
+		product.Mul(x, y)
+	}
+}
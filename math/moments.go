@@ -0,0 +1,214 @@
+package litearray
+
+import "math"
+
+// Skewness calculates the skewness of arrays of equal length element-wise: the third central
+// moment over the variance to the power 3/2, as in SciPy's skew. By default it is the moment
+// estimator g₁; WithBiasCorrection gives the adjusted estimator G₁, which needs three values.
+// Positions whose values are all equal have NaN skewness.
+func Skewness(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return laneStatistic("Skewness", arrays, opts, "", nil, func(c *config, values []float64) float64 {
+		return laneSkewness(values, c.biasCorrected)
+	})
+}
+
+// Kurtosis calculates the excess kurtosis of arrays of equal length element-wise: the fourth
+// central moment over the squared variance, less 3 so that the normal distribution scores zero,
+// as in SciPy's kurtosis. By default it is the moment estimator g₂; WithBiasCorrection gives the
+// adjusted estimator G₂, which needs four values. Positions whose values are all equal have NaN
+// kurtosis.
+func Kurtosis(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return laneStatistic("Kurtosis", arrays, opts, "", nil, func(c *config, values []float64) float64 {
+		return laneKurtosis(values, c.biasCorrected)
+	})
+}
+
+// CentralMoment calculates the central moment of the given order of arrays of equal length
+// element-wise, the mean of (x − x̄)^order. The first central moment is exactly zero and the
+// second is the population variance. The order must not be negative.
+func CentralMoment(order int, arrays [][]float64, opts ...Option) ([]float64, error) {
+	const op = "CentralMoment"
+	if order < 0 {
+		return nil, argumentError(op, "moment order cannot be negative, got %d", order)
+	}
+	return laneStatistic(op, arrays, opts, "", nil, func(c *config, values []float64) float64 {
+		return laneCentralMoment(values, order)
+	})
+}
+
+// RawMoment calculates the raw moment of the given order of arrays of equal length element-wise,
+// the mean of x^order. The order must not be negative.
+func RawMoment(order int, arrays [][]float64, opts ...Option) ([]float64, error) {
+	const op = "RawMoment"
+	if order < 0 {
+		return nil, argumentError(op, "moment order cannot be negative, got %d", order)
+	}
+	return laneStatistic(op, arrays, opts, "", nil, func(c *config, values []float64) float64 {
+		sum := 0.0
+		for _, v := range values {
+			sum += math.Pow(v, float64(order))
+		}
+		return sum / float64(len(values))
+	})
+}
+
+// GeometricMean calculates the geometric mean of arrays of equal length element-wise, the nth
+// root of the product of n values, computed through logarithms so that it cannot overflow. A
+// negative value is reported as ErrDomain; a zero makes the mean zero.
+func GeometricMean(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return laneStatistic("GeometricMean", arrays, opts, "geometric mean undefined for negative values at index %d", isNonNegative, func(c *config, values []float64) float64 {
+		sum := 0.0
+		for _, v := range values {
+			sum += math.Log(v)
+		}
+		return math.Exp(sum / float64(len(values)))
+	})
+}
+
+// HarmonicMean calculates the harmonic mean of arrays of equal length element-wise, the
+// reciprocal of the mean of the reciprocals. A negative value is reported as ErrDomain; a zero
+// makes the mean zero.
+func HarmonicMean(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return laneStatistic("HarmonicMean", arrays, opts, "harmonic mean undefined for negative values at index %d", isNonNegative, func(c *config, values []float64) float64 {
+		sum := 0.0
+		for _, v := range values {
+			sum += 1 / v
+		}
+		return float64(len(values)) / sum
+	})
+}
+
+// TrimmedMean calculates the mean of arrays of equal length element-wise after discarding the
+// given proportion of the values at each position from either end, rounded down to whole values
+// as in SciPy's trim_mean. The proportion must be at least 0 and less than 0.5. The values to
+// discard are found by selection, so each position takes linear time.
+func TrimmedMean(proportion float64, arrays [][]float64, opts ...Option) ([]float64, error) {
+	const op = "TrimmedMean"
+	if !(proportion >= 0 && proportion < 0.5) {
+		return nil, argumentError(op, "proportion to trim must be at least 0 and less than 0.5, got %v", proportion)
+	}
+	return laneStatistic(op, arrays, opts, "", nil, func(c *config, values []float64) float64 {
+		// Selection would move a NaN to an arbitrary end, so it propagates before anything is cut
+		for _, v := range values {
+			if math.IsNaN(v) {
+				return math.NaN()
+			}
+		}
+		n := len(values)
+		cut := int(proportion * float64(n))
+		if cut > 0 {
+			selectKth(values, cut)
+			selectKth(values[cut:], n-2*cut-1)
+		}
+		return laneMean(values[cut : n-cut])
+	})
+}
+
+// CoefficientOfVariation calculates the standard deviation over the mean of arrays of equal
+// length element-wise, as in SciPy's variation. WithDDOF applies to the standard deviation. A
+// zero mean gives ±Inf, or NaN when the values are all zero.
+func CoefficientOfVariation(arrays [][]float64, opts ...Option) ([]float64, error) {
+	return laneStatistic("CoefficientOfVariation", arrays, opts, "", nil, func(c *config, values []float64) float64 {
+		return math.Sqrt(laneVariance(values, c.ddof)) / laneMean(values)
+	})
+}
+
+// laneStatistic calculates a statistic of the values at each position of arrays of equal length,
+// with the checks, NaN handling and rounding the statistics functions share. When inDomain is not
+// nil, a value outside it is reported as ErrDomain with the message domain.
+func laneStatistic(op string, arrays [][]float64, opts []Option, domain string, inDomain func(float64) bool, fn func(c *config, values []float64) float64) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that there is something to summarize and all arrays are the same length
+	if len(arrays) == 0 {
+		return nil, emptyError(op, nil, "no arrays provided")
+	}
+	if err := sameLength(op, arrays); err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, arrays...); err != nil {
+		return nil, err
+	}
+
+	// Reject values outside the domain before writing anything
+	if inDomain != nil {
+		for _, array := range arrays {
+			for i, v := range array {
+				if !math.IsNaN(v) && !inDomain(v) {
+					return nil, valueError(op, ErrDomain, i, v, domain, i)
+				}
+			}
+		}
+	}
+
+	result, err := c.result(op, len(arrays[0]))
+	if err != nil {
+		return nil, err
+	}
+	c.positionwise(result, arrays, func(values []float64) float64 {
+		return fn(c, values)
+	})
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(arrays...), result)
+
+	return result, nil
+}
+
+// isNonNegative reports whether v is at least zero.
+func isNonNegative(v float64) bool {
+	return v >= 0
+}
+
+// laneCentralMoment calculates the central moment of the given order of a non-empty lane.
+func laneCentralMoment(lane []float64, order int) float64 {
+	if order == 1 {
+		return 0
+	}
+	mean := laneMean(lane)
+	sum := 0.0
+	for _, v := range lane {
+		sum += math.Pow(v-mean, float64(order))
+	}
+	return sum / float64(len(lane))
+}
+
+// laneSpread returns the second central moment of a non-empty lane, or NaN when it is zero up to
+// the rounding error in the mean, so that constant lanes have no shape.
+func laneSpread(lane []float64) float64 {
+	m2 := laneCentralMoment(lane, 2)
+	if resolution := 1e-15 * laneMean(lane); m2 <= resolution*resolution {
+		return math.NaN()
+	}
+	return m2
+}
+
+// laneSkewness calculates the skewness of a non-empty lane, bias-corrected if asked.
+func laneSkewness(lane []float64, corrected bool) float64 {
+	n := float64(len(lane))
+	g1 := laneCentralMoment(lane, 3) / math.Pow(laneSpread(lane), 1.5)
+	if !corrected {
+		return g1
+	}
+	if n < 3 {
+		return math.NaN()
+	}
+	return g1 * math.Sqrt(n*(n-1)) / (n - 2)
+}
+
+// laneKurtosis calculates the excess kurtosis of a non-empty lane, bias-corrected if asked.
+func laneKurtosis(lane []float64, corrected bool) float64 {
+	n := float64(len(lane))
+	m2 := laneSpread(lane)
+	g2 := laneCentralMoment(lane, 4)/(m2*m2) - 3
+	if !corrected {
+		return g2
+	}
+	if n < 4 {
+		return math.NaN()
+	}
+	return ((n+1)*g2 + 6) * (n - 1) / ((n - 2) * (n - 3))
+}
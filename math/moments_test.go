@@ -0,0 +1,123 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// momentSample holds the samples {2, 8, 0, 4, 1, 9, 9, 0} and {1, …, 8} at its two positions.
+var momentSample = [][]float64{{2, 1}, {8, 2}, {0, 3}, {4, 4}, {1, 5}, {9, 6}, {9, 7}, {0, 8}}
+
+func TestShapeStatistics(t *testing.T) {
+	// Test case 1: Biased and bias-corrected skewness and excess kurtosis, against SciPy
+	skewness, err := Skewness(momentSample)
+	if err != nil || !compareSlices(skewness, []float64{0.2650554122698573, 0}, 1e-12) {
+		t.Errorf("Unexpected skewness %v (err %v)", skewness, err)
+	}
+	skewness, _ = Skewness(momentSample, WithBiasCorrection(true))
+	if !compareSlices(skewness, []float64{0.33058218040797466, 0}, 1e-12) {
+		t.Errorf("Unexpected corrected skewness %v", skewness)
+	}
+	kurtosis, err := Kurtosis(momentSample)
+	if err != nil || !compareSlices(kurtosis, []float64{-1.6660010752838508, -1.2380952380952381}, 1e-12) {
+		t.Errorf("Unexpected kurtosis %v (err %v)", kurtosis, err)
+	}
+	kurtosis, _ = Kurtosis(momentSample, WithBiasCorrection(true), WithPrecision(4))
+	if !compareSlices(kurtosis, []float64{-2.0986, -1.2}, 0) {
+		t.Errorf("Unexpected corrected kurtosis %v", kurtosis)
+	}
+
+	// Test case 2: Constant positions and samples too small to correct have no shape
+	skewness, _ = Skewness([][]float64{{0.1, 1}, {0.1, 2}, {0.1, 4}})
+	kurtosis, _ = Kurtosis([][]float64{{0.1, 1}, {0.1, 2}, {0.1, 4}}, WithBiasCorrection(true))
+	if !math.IsNaN(skewness[0]) || math.IsNaN(skewness[1]) || !math.IsNaN(kurtosis[0]) || !math.IsNaN(kurtosis[1]) {
+		t.Errorf("Expected NaN for the constant position and the corrected kurtosis of three values, got %v and %v", skewness, kurtosis)
+	}
+
+	// Test case 3: Central and raw moments
+	central, err := CentralMoment(3, momentSample)
+	if err != nil || !compareSlices(central, []float64{13.67578125, 0}, 1e-12) {
+		t.Errorf("Expected [13.67578125 0], got %v (err %v)", central, err)
+	}
+	central, _ = CentralMoment(1, momentSample)
+	variance, _ := Variance(momentSample)
+	second, _ := CentralMoment(2, momentSample)
+	if !compareSlices(central, []float64{0, 0}, 0) || !compareSlices(second, variance, 1e-12) {
+		t.Errorf("Expected a zero first moment and the variance as the second, got %v and %v", central, second)
+	}
+	raw, err := RawMoment(3, momentSample)
+	if err != nil || !compareSlices(raw, []float64{255.375, 162}, 1e-12) {
+		t.Errorf("Expected [255.375 162], got %v (err %v)", raw, err)
+	}
+
+	// Test case 4: Invalid input
+	if _, err := CentralMoment(-1, momentSample); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := Skewness(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := Kurtosis([][]float64{{1, 2}, {3}}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
+
+func TestMeansAndVariation(t *testing.T) {
+	// Test case 1: Geometric and harmonic means, with zeros making them zero
+	geometric, err := GeometricMean(momentSample)
+	harmonic, _ := HarmonicMean(momentSample)
+	if err != nil || !compareSlices(geometric, []float64{0, 3.764350599503129}, 1e-12) || !compareSlices(harmonic, []float64{0, 2.9434954007884366}, 1e-12) {
+		t.Errorf("Unexpected means %v and %v (err %v)", geometric, harmonic, err)
+	}
+	geometric, _ = GeometricMean([][]float64{{1e300}, {1e300}, {1e300}})
+	if !compareSlices(geometric, []float64{1e300}, 1e288) {
+		t.Errorf("Expected [1e300] without overflow, got %v", geometric)
+	}
+
+	// Test case 2: Trimmed means leave the sample in place and ignore outliers
+	trimmed, err := TrimmedMean(0.25, momentSample)
+	if err != nil || !compareSlices(trimmed, []float64{3.75, 4.5}, 1e-12) {
+		t.Errorf("Expected [3.75 4.5], got %v (err %v)", trimmed, err)
+	}
+	if momentSample[1][0] != 8 || momentSample[7][0] != 0 {
+		t.Errorf("Expected the input to be left alone, got %v", momentSample)
+	}
+	trimmed, _ = TrimmedMean(0.2, [][]float64{{1}, {2}, {3}, {4}, {1e9}})
+	untrimmed, _ := TrimmedMean(0, [][]float64{{1}, {2}, {3}})
+	if !compareSlices(trimmed, []float64{3}, 1e-12) || !compareSlices(untrimmed, []float64{2}, 0) {
+		t.Errorf("Expected [3] and [2], got %v and %v", trimmed, untrimmed)
+	}
+
+	// Test case 3: Coefficient of variation, honoring ddof
+	variation, err := CoefficientOfVariation(momentSample)
+	if err != nil || !compareSlices(variation, []float64{0.9025013704142686, 0.5091750772173156}, 1e-12) {
+		t.Errorf("Unexpected coefficient of variation %v (err %v)", variation, err)
+	}
+	variation, _ = CoefficientOfVariation(momentSample, WithDDOF(1))
+	if !compareSlices(variation, []float64{0.9648145483383294, 0.5443310539518174}, 1e-12) {
+		t.Errorf("Unexpected sample coefficient of variation %v", variation)
+	}
+
+	// Test case 4: NaN handling and invalid input
+	harmonic, _ = HarmonicMean([][]float64{{1, math.NaN()}, {4, 2}}, WithNaNPolicy(NaNOmit))
+	if !compareSlices(harmonic, []float64{1.6, 2}, 1e-12) {
+		t.Errorf("Expected [1.6 2], got %v", harmonic)
+	}
+	for _, arrays := range [][][]float64{{{1}, {math.NaN()}, {3}, {4}, {5}}, {{math.NaN()}, {1}, {3}, {4}, {5}}} {
+		if trimmed, _ := TrimmedMean(0.2, arrays); !compareSlices(trimmed, []float64{math.NaN()}, 0) {
+			t.Errorf("Expected [NaN] for %v, got %v", arrays, trimmed)
+		}
+	}
+	trimmed, _ = TrimmedMean(0.2, [][]float64{{math.NaN()}, {1}, {3}, {4}, {5}, {9}}, WithNaNPolicy(NaNOmit))
+	if !compareSlices(trimmed, []float64{4}, 1e-12) {
+		t.Errorf("Expected [4] without the NaN, got %v", trimmed)
+	}
+	var valueErr *ValueError
+	if _, err := GeometricMean([][]float64{{1, 2}, {3, -4}}); !errors.Is(err, ErrDomain) || !errors.As(err, &valueErr) || valueErr.Index != 1 {
+		t.Errorf("Expected ErrDomain at index 1, got %v", err)
+	}
+	if _, err := TrimmedMean(0.5, momentSample); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
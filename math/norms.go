@@ -0,0 +1,235 @@
+package litearray
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// MatrixNormKind selects the matrix norm computed by MatrixNorm.
+type MatrixNormKind int
+
+const (
+	// NormFrobenius is the square root of the sum of squared entries.
+	NormFrobenius MatrixNormKind = iota
+	// NormNuclear is the sum of the singular values.
+	NormNuclear
+	// NormSpectral is the largest singular value, the norm induced by the vector 2-norm.
+	NormSpectral
+	// NormOne is the largest absolute column sum, the norm induced by the vector 1-norm.
+	NormOne
+	// NormInf is the largest absolute row sum, the norm induced by the vector ∞-norm.
+	NormInf
+)
+
+// Norm calculates the p-norm (Σ|xᵢ|ᵖ)^(1/p) of a vector. Use p = 1 for the L1 norm, p = 2 for the
+// Euclidean norm and p = math.Inf(1) for the maximum absolute value; any other positive p gives the
+// general p-norm. The 2-norm is computed with scaling, so it does not overflow for large entries.
+// The norm of an empty vector is 0.
+func Norm(x []float64, p float64, opts ...Option) (float64, error) {
+	const op = "Norm"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkOrder(op, p); err != nil {
+		return 0, err
+	}
+	if err := c.checkNaN(op, x); err != nil {
+		return 0, err
+	}
+
+	result := []float64{floats.Norm(x, p)}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(x), result)
+
+	return result[0], nil
+}
+
+// MatrixNorm calculates the norm of an m×n matrix selected by kind. The nuclear and spectral norms
+// need the singular values and cost O(mn·min(m, n)); the others take a single pass.
+func MatrixNorm(matrix [][]float64, kind MatrixNormKind, opts ...Option) (float64, error) {
+	const op = "MatrixNorm"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return 0, err
+	}
+	if kind < NormFrobenius || kind > NormInf {
+		return 0, argumentError(op, "unknown matrix norm kind %d", int(kind))
+	}
+	n, err := checkRectangular(op, matrix)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, emptyError(op, matrixShapes(matrix), "matrix cannot be empty")
+	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return 0, err
+	}
+
+	var norm float64
+	switch kind {
+	case NormFrobenius:
+		norm = frobenius(matrix)
+	case NormNuclear, NormSpectral:
+		s, err := singularValues(op, matrix)
+		if err != nil {
+			return 0, err
+		}
+		norm = s[0]
+		if kind == NormNuclear {
+			norm = floats.Sum(s)
+		}
+	case NormOne:
+		norm = normOne(matrix)
+	case NormInf:
+		for _, row := range matrix {
+			norm = max(norm, floats.Norm(row, 1))
+		}
+	}
+	result := []float64{norm}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), result)
+
+	return result[0], nil
+}
+
+// Normalize scales a vector to unit p-norm, with p as in Norm. A vector whose norm is zero cannot
+// be normalized and is reported as ErrDivideByZero. WithOut may alias x.
+func Normalize(x []float64, p float64, opts ...Option) ([]float64, error) {
+	const op = "Normalize"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOrder(op, p); err != nil {
+		return nil, err
+	}
+	if len(x) == 0 {
+		return nil, emptyError(op, shapesOf(x), "vector cannot be empty")
+	}
+	if err := c.checkNaN(op, x); err != nil {
+		return nil, err
+	}
+
+	norm := floats.Norm(x, p)
+	if norm == 0 {
+		return nil, valueError(op, ErrDivideByZero, -1, 0, "cannot normalize a vector with zero norm")
+	}
+	result, err := c.result(op, len(x))
+	if err != nil {
+		return nil, err
+	}
+	floats.ScaleTo(result, 1/norm, x)
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(x), result)
+
+	return result, nil
+}
+
+// EuclideanDistance calculates the straight-line distance ‖x − y‖₂ between two vectors. Like
+// AddArrays, a vector holding a single element is broadcast against the other.
+func EuclideanDistance(x, y []float64, opts ...Option) (float64, error) {
+	return minkowski("EuclideanDistance", x, y, 2, opts)
+}
+
+// ManhattanDistance calculates the city-block distance ‖x − y‖₁ between two vectors.
+func ManhattanDistance(x, y []float64, opts ...Option) (float64, error) {
+	return minkowski("ManhattanDistance", x, y, 1, opts)
+}
+
+// ChebyshevDistance calculates the largest absolute difference ‖x − y‖∞ between two vectors.
+func ChebyshevDistance(x, y []float64, opts ...Option) (float64, error) {
+	return minkowski("ChebyshevDistance", x, y, math.Inf(1), opts)
+}
+
+// MinkowskiDistance calculates the p-norm distance ‖x − y‖ₚ between two vectors for any positive
+// p, including math.Inf(1).
+func MinkowskiDistance(x, y []float64, p float64, opts ...Option) (float64, error) {
+	return minkowski("MinkowskiDistance", x, y, p, opts)
+}
+
+func minkowski(op string, x, y []float64, p float64, opts []Option) (float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkOrder(op, p); err != nil {
+		return 0, err
+	}
+	diff, err := c.difference(op, x, y)
+	if err != nil {
+		return 0, err
+	}
+
+	result := []float64{floats.Norm(diff, p)}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(x, y), result)
+
+	return result[0], nil
+}
+
+// CosineDistance calculates 1 − x·y/(‖x‖₂‖y‖₂), which is 0 for vectors pointing the same way, 1
+// for orthogonal vectors and 2 for opposite ones. It is undefined when either vector is zero,
+// which is reported as ErrDivideByZero.
+func CosineDistance(x, y []float64, opts ...Option) (float64, error) {
+	const op = "CosineDistance"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return 0, err
+	}
+	length, err := checkOperands(op, [][]float64{x, y}, false, "")
+	if err != nil {
+		return 0, err
+	}
+	if err := c.checkNaN(op, x, y); err != nil {
+		return 0, err
+	}
+
+	var dot, xx, yy float64
+	for i := 0; i < length; i++ {
+		a, b := x[i%len(x)], y[i%len(y)]
+		dot += a * b
+		xx += a * a
+		yy += b * b
+	}
+	if xx == 0 || yy == 0 {
+		return 0, valueError(op, ErrDivideByZero, -1, 0, "cosine distance is undefined for a zero vector")
+	}
+	result := []float64{1 - dot/(math.Sqrt(xx)*math.Sqrt(yy))}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(x, y), result)
+
+	return result[0], nil
+}
+
+// difference validates two vectors like AddArrays and returns x − y, broadcasting a vector that
+// holds a single element.
+func (c *config) difference(op string, x, y []float64) ([]float64, error) {
+	length, err := checkOperands(op, [][]float64{x, y}, false, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, x, y); err != nil {
+		return nil, err
+	}
+	diff := make([]float64, length)
+	for i := range diff {
+		diff[i] = x[i%len(x)] - y[i%len(y)]
+	}
+	return diff, nil
+}
+
+// checkOrder checks that p is a valid norm order: positive, possibly +Inf.
+func checkOrder(op string, p float64) error {
+	if !(p > 0) {
+		return argumentError(op, "norm order must be positive, got %g", p)
+	}
+	return nil
+}
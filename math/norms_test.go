@@ -0,0 +1,139 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNorm(t *testing.T) {
+	x := []float64{3, -4}
+
+	// Test case 1: L1, L2, L∞ and a general p-norm
+	cases := []struct {
+		p        float64
+		expected float64
+	}{
+		{1, 7},
+		{2, 5},
+		{math.Inf(1), 4},
+		{3, math.Cbrt(91)},
+	}
+	for _, tc := range cases {
+		norm, err := Norm(x, tc.p)
+		if err != nil || math.Abs(norm-tc.expected) > 1e-12 {
+			t.Errorf("Expected %v for p = %v, got %v (err %v)", tc.expected, tc.p, norm, err)
+		}
+	}
+
+	// Test case 2: The 2-norm does not overflow
+	norm, err := Norm([]float64{3e300, 4e300}, 2)
+	if err != nil || math.Abs(norm-5e300) > 1e288 {
+		t.Errorf("Expected 5e300, got %v (err %v)", norm, err)
+	}
+
+	// Test case 3: Invalid orders
+	for _, p := range []float64{0, -1, math.NaN(), math.Inf(-1)} {
+		if _, err := Norm(x, p); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Expected ErrInvalidArgument for p = %v, got %v", p, err)
+		}
+	}
+}
+
+func TestMatrixNorm(t *testing.T) {
+	matrix := [][]float64{{1, -2}, {-3, 4}}
+
+	// Test case 1: Every kind of matrix norm
+	cases := []struct {
+		kind     MatrixNormKind
+		expected float64
+	}{
+		{NormFrobenius, math.Sqrt(30)},
+		{NormNuclear, math.Sqrt(30 + 2*2)},
+		{NormSpectral, math.Sqrt(15 + math.Sqrt(221))},
+		{NormOne, 6},
+		{NormInf, 7},
+	}
+	for _, tc := range cases {
+		norm, err := MatrixNorm(matrix, tc.kind)
+		if err != nil || math.Abs(norm-tc.expected) > 1e-12 {
+			t.Errorf("Expected %v for kind %d, got %v (err %v)", tc.expected, tc.kind, norm, err)
+		}
+	}
+
+	// Test case 2: Invalid input
+	if _, err := MatrixNorm(matrix, MatrixNormKind(9)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := MatrixNorm([][]float64{{}}, NormOne); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	// Test case 1: Unit 2-norm and 1-norm
+	unit, err := Normalize([]float64{3, 4}, 2)
+	if err != nil || !compareSlices(unit, []float64{0.6, 0.8}, 1e-15) {
+		t.Errorf("Expected [0.6 0.8], got %v (err %v)", unit, err)
+	}
+	unit, err = Normalize([]float64{1, 3}, 1)
+	if err != nil || !compareSlices(unit, []float64{0.25, 0.75}, 0) {
+		t.Errorf("Expected [0.25 0.75], got %v (err %v)", unit, err)
+	}
+
+	// Test case 2: In place
+	x := []float64{0, -2}
+	if _, err := Normalize(x, 2, WithOut(x)); err != nil || !compareSlices(x, []float64{0, -1}, 0) {
+		t.Errorf("Expected [0 -1], got %v (err %v)", x, err)
+	}
+
+	// Test case 3: The zero vector
+	if _, err := Normalize([]float64{0, 0}, 2); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v", err)
+	}
+}
+
+func TestDistances(t *testing.T) {
+	x, y := []float64{1, 2, 3}, []float64{4, 6, 3}
+
+	// Test case 1: Each distance between the same pair of vectors
+	euclidean, _ := EuclideanDistance(x, y)
+	manhattan, _ := ManhattanDistance(x, y)
+	chebyshev, _ := ChebyshevDistance(x, y)
+	minkowski, _ := MinkowskiDistance(x, y, 3)
+	if euclidean != 5 || manhattan != 7 || chebyshev != 4 || math.Abs(minkowski-math.Cbrt(91)) > 1e-12 {
+		t.Errorf("Expected 5, 7, 4 and ∛91, got %v, %v, %v and %v", euclidean, manhattan, chebyshev, minkowski)
+	}
+
+	// Test case 2: Cosine distance of parallel, orthogonal and opposite vectors
+	for _, tc := range []struct {
+		y        []float64
+		expected float64
+	}{
+		{[]float64{2, 4, 6}, 0},
+		{[]float64{3, 0, -1}, 1},
+		{[]float64{-1, -2, -3}, 2},
+	} {
+		distance, err := CosineDistance(x, tc.y)
+		if err != nil || math.Abs(distance-tc.expected) > 1e-12 {
+			t.Errorf("Expected %v for %v, got %v (err %v)", tc.expected, tc.y, distance, err)
+		}
+	}
+
+	// Test case 3: Single-element vectors broadcast like AddArrays
+	distance, err := ManhattanDistance(x, []float64{2}, WithPrecision(1))
+	if err != nil || distance != 2 {
+		t.Errorf("Expected 2, got %v (err %v)", distance, err)
+	}
+
+	// Test case 4: Invalid input
+	if _, err := EuclideanDistance(x, []float64{1, 2}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := CosineDistance(x, []float64{0, 0, 0}); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v", err)
+	}
+	if _, err := MinkowskiDistance(x, y, 0); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
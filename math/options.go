@@ -0,0 +1,315 @@
+package litearray
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Option configures a single call of a function that accepts options.
+type Option func(*config)
+
+// NaNPolicy selects how functions treat NaN inputs.
+type NaNPolicy int
+
+const (
+	// NaNPropagate lets NaN inputs flow into the results. It is the default.
+	NaNPropagate NaNPolicy = iota
+	// NaNRaise rejects any NaN input with a ValueError wrapping ErrDomain.
+	NaNRaise
+	// NaNOmit ignores NaN inputs in the statistics functions, which aggregate several values
+	// into each result. Elsewhere it behaves like NaNPropagate.
+	NaNOmit
+)
+
+// config holds the settings of a single call, filled in from its options.
+type config struct {
+	precision   int
+	rounding    RoundingMode
+	out         []float64
+	parallelism int
+	nanPolicy   NaNPolicy
+	logger      *slog.Logger
+	tolerance   float64 // negative until WithTolerance is given
+
+	quantileMethod QuantileMethod
+	methodChosen   bool // whether WithQuantileMethod was given
+	ddof           int
+	biasCorrected  bool
+	weights        []float64
+	weightKind     WeightKind
+
+	// Settings of the iterative solvers
+	maxIterations  int // zero until WithMaxIterations is given
+	restart        int // zero until WithRestart is given
+	preconditioner Preconditioner
+	initialGuess   []float64
+}
+
+// minChunk is the smallest number of elements handed to a goroutine when running in parallel.
+const minChunk = 1024
+
+// WithPrecision rounds results to precision decimal places, or significant figures with
+// RoundSignificant. It must be between MinPrecision and MaxPrecision; -1, the default,
+// disables rounding.
+func WithPrecision(precision int) Option {
+	return func(c *config) { c.precision = precision }
+}
+
+// WithRounding selects the rounding mode for this call, overriding SetRoundingMode.
+func WithRounding(mode RoundingMode) Option {
+	return func(c *config) { c.rounding = mode }
+}
+
+// WithOut writes the results into dst instead of allocating a new slice. dst must have exactly
+// as many elements as the result and may be one of the inputs for in-place updates.
+// Functions returning a matrix use dst as the row-major backing buffer of the rows.
+func WithOut(dst []float64) Option {
+	return func(c *config) { c.out = dst }
+}
+
+// WithParallelism splits the work of element-wise functions across up to n goroutines.
+// Zero uses runtime.GOMAXPROCS(0) goroutines; the default of one runs sequentially.
+func WithParallelism(n int) Option {
+	return func(c *config) { c.parallelism = n }
+}
+
+// WithNaNPolicy selects how NaN inputs are treated. The default is NaNPropagate.
+func WithNaNPolicy(policy NaNPolicy) Option {
+	return func(c *config) { c.nanPolicy = policy }
+}
+
+// WithTolerance sets the tolerance of functions that need one, such as the threshold below which
+// singular values count as zero. Each such function documents its default. The tolerance must be
+// a non-negative number.
+func WithTolerance(tolerance float64) Option {
+	return func(c *config) {
+		c.tolerance = tolerance
+		if tolerance < 0 {
+			c.tolerance = math.NaN()
+		}
+	}
+}
+
+// WithQuantileMethod selects how Quantiles, Percentile and Median estimate quantiles. The default
+// is QuantileLinear, or QuantileAveragedInvertedCDF with WithWeights.
+func WithQuantileMethod(method QuantileMethod) Option {
+	return func(c *config) {
+		c.quantileMethod = method
+		c.methodChosen = true
+	}
+}
+
+// WithDDOF sets the delta degrees of freedom of variances and standard deviations, which divide
+// by N − ddof for N values. The default of 0 gives the population variance and 1 the unbiased
+// sample variance. It must not be negative.
+func WithDDOF(ddof int) Option {
+	return func(c *config) { c.ddof = ddof }
+}
+
+// WithBiasCorrection selects the bias-corrected sample estimators of Skewness and Kurtosis, G₁
+// and G₂, instead of the default moment estimators g₁ and g₂ that are biased in small samples.
+func WithBiasCorrection(corrected bool) Option {
+	return func(c *config) { c.biasCorrected = corrected }
+}
+
+// WithWeights weights the observations of the statistics functions that document support for it;
+// the others reject weights rather than ignore them. kind says whether the weights count repeated
+// observations or measure their reliability, which matters once ddof is non-zero. Weights must be
+// finite, non-negative and not all zero.
+func WithWeights(weights []float64, kind WeightKind) Option {
+	return func(c *config) {
+		c.weights = weights
+		c.weightKind = kind
+	}
+}
+
+// WithMaxIterations limits the number of iterations of the iterative solvers. Each solver
+// documents its default. The limit must be positive.
+func WithMaxIterations(n int) Option {
+	return func(c *config) {
+		c.maxIterations = n
+		if n <= 0 {
+			c.maxIterations = -1
+		}
+	}
+}
+
+// WithRestart sets the number of iterations GMRES runs before restarting. It must be positive.
+func WithRestart(m int) Option {
+	return func(c *config) {
+		c.restart = m
+		if m <= 0 {
+			c.restart = -1
+		}
+	}
+}
+
+// WithPreconditioner speeds up the iterative solvers with a preconditioner such as NewJacobi or
+// NewILU. The default is no preconditioning.
+func WithPreconditioner(p Preconditioner) Option {
+	return func(c *config) { c.preconditioner = p }
+}
+
+// WithInitialGuess starts the iterative solvers from x0 instead of the zero vector.
+func WithInitialGuess(x0 []float64) Option {
+	return func(c *config) { c.initialGuess = x0 }
+}
+
+// WithLogger traces the rounding decisions of this call to l, overriding SetLogger.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *config) { c.logger = l }
+}
+
+// newConfig applies opts on top of the package defaults and validates the result, so every
+// function can reject bad options before doing any work.
+func newConfig(op string, opts []Option) (*config, error) {
+	c := &config{
+		precision:   -1,
+		rounding:    RoundingMode(roundingMode.Load()),
+		parallelism: 1,
+		logger:      logger.Load(),
+		tolerance:   -1,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// Validate every setting up front
+	if err := checkPrecision(op, c.precision); err != nil {
+		return nil, err
+	}
+	if c.rounding < RoundHalfAwayFromZero || c.rounding > RoundSignificant {
+		return nil, argumentError(op, "unknown rounding mode %d", int(c.rounding))
+	}
+	if c.rounding == RoundSignificant && c.precision == 0 {
+		return nil, &PrecisionError{Op: op, Precision: c.precision}
+	}
+	if c.parallelism < 0 {
+		return nil, argumentError(op, "parallelism cannot be negative, got %d", c.parallelism)
+	}
+	if c.parallelism == 0 {
+		c.parallelism = runtime.GOMAXPROCS(0)
+	}
+	if c.nanPolicy < NaNPropagate || c.nanPolicy > NaNOmit {
+		return nil, argumentError(op, "unknown NaN policy %d", int(c.nanPolicy))
+	}
+	if math.IsNaN(c.tolerance) || math.IsInf(c.tolerance, 0) {
+		return nil, argumentError(op, "tolerance must be a non-negative number")
+	}
+	if c.quantileMethod < QuantileLinear || c.quantileMethod > QuantileMidpoint {
+		return nil, argumentError(op, "unknown quantile method %d", int(c.quantileMethod))
+	}
+	if c.weights != nil && !weightedOps[op] {
+		return nil, argumentError(op, "weights are not supported")
+	}
+	if c.weights != nil && !c.methodChosen {
+		c.quantileMethod = QuantileAveragedInvertedCDF
+	}
+	if c.ddof < 0 {
+		return nil, argumentError(op, "delta degrees of freedom cannot be negative, got %d", c.ddof)
+	}
+	if c.weightKind < FrequencyWeights || c.weightKind > ReliabilityWeights {
+		return nil, argumentError(op, "unknown weight kind %d", int(c.weightKind))
+	}
+	if c.maxIterations < 0 {
+		return nil, argumentError(op, "maximum number of iterations must be positive")
+	}
+	if c.restart < 0 {
+		return nil, argumentError(op, "restart length must be positive")
+	}
+
+	return c, nil
+}
+
+// result returns the slice the results of op should be written to: the WithOut destination
+// when there is one, or a new slice of the given length.
+func (c *config) result(op string, length int) ([]float64, error) {
+	if c.out == nil {
+		return make([]float64, length), nil
+	}
+	if len(c.out) != length {
+		return nil, shapeError(op, [][]int{{len(c.out)}, {length}}, "output slice has length %d, expected %d", len(c.out), length)
+	}
+	return c.out, nil
+}
+
+// checkNaN rejects NaN inputs under NaNRaise.
+func (c *config) checkNaN(op string, arrays ...[]float64) error {
+	if c.nanPolicy != NaNRaise {
+		return nil
+	}
+	for _, array := range arrays {
+		for i, v := range array {
+			if math.IsNaN(v) {
+				return valueError(op, ErrDomain, i, v, "NaN value at index %d", i)
+			}
+		}
+	}
+	return nil
+}
+
+// toleranceOr returns the tolerance given with WithTolerance, or fallback when there is none.
+func (c *config) toleranceOr(fallback float64) float64 {
+	if c.tolerance < 0 {
+		return fallback
+	}
+	return c.tolerance
+}
+
+// omitNaN reports whether NaN inputs should be skipped.
+func (c *config) omitNaN() bool {
+	return c.nanPolicy == NaNOmit
+}
+
+// round rounds values in place according to the call's precision and rounding mode, tracing
+// each decision to the call's logger.
+func (c *config) round(op string, shapes [][]int, values []float64) {
+	if c.precision < 0 {
+		return
+	}
+
+	// Only pay for tracing when a logger wants debug output
+	l := c.logger
+	tracing := l != nil && l.Enabled(context.Background(), slog.LevelDebug)
+	if tracing {
+		l.Debug("rounding results", "op", op, "shapes", shapes, "precision", c.precision, "mode", c.rounding, "count", len(values))
+	}
+
+	for i, before := range values {
+		values[i] = Round(before, c.precision, c.rounding)
+		if tracing {
+			l.Debug("rounded value", "op", op, "index", i, "before", before, "after", values[i])
+		}
+	}
+}
+
+// parallel calls fn on consecutive chunks of [0, n), running the chunks on up to c.parallelism
+// goroutines. Small inputs run on the calling goroutine.
+func (c *config) parallel(n int, fn func(lo, hi int)) {
+	c.parallelChunks(n, minChunk, fn)
+}
+
+// parallelChunks is like parallel but lets the caller choose the smallest chunk worth handing to
+// its own goroutine, for work where each unit is expensive.
+func (c *config) parallelChunks(n, smallest int, fn func(lo, hi int)) {
+	workers := min(c.parallelism, (n+smallest-1)/smallest)
+	if workers <= 1 {
+		fn(0, n)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunk {
+		hi := min(lo+chunk, n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(lo, hi)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,169 @@
+package litearray
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestOptionsValidation(t *testing.T) {
+	// Test case 1: Precision is validated before the inputs
+	_, err := Add(nil, WithPrecision(11))
+	if !errors.Is(err, ErrPrecisionRange) {
+		t.Errorf("Expected ErrPrecisionRange, got %v", err)
+	}
+
+	// Test case 2: The legacy wrappers validate precision up front too
+	_, err = MedianArrays(11, []float64{1, 2}, []float64{3, 4})
+	if !errors.Is(err, ErrPrecisionRange) {
+		t.Errorf("Expected ErrPrecisionRange from MedianArrays, got %v", err)
+	}
+	_, err = TransposeMatrix(-2, [][]float64{{1}})
+	if !errors.Is(err, ErrPrecisionRange) {
+		t.Errorf("Expected ErrPrecisionRange from TransposeMatrix, got %v", err)
+	}
+
+	// Test case 3: Unknown rounding modes and NaN policies, and negative parallelism
+	for _, opt := range []Option{WithRounding(RoundingMode(42)), WithNaNPolicy(NaNPolicy(7)), WithParallelism(-1)} {
+		_, err = Mean([][]float64{{1}, {2}}, opt)
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Expected ErrInvalidArgument, got %v", err)
+		}
+	}
+
+	// Test case 4: Zero significant figures
+	_, err = Add([][]float64{{1}, {2}}, WithPrecision(0), WithRounding(RoundSignificant))
+	if !errors.Is(err, ErrPrecisionRange) {
+		t.Errorf("Expected ErrPrecisionRange for zero significant figures, got %v", err)
+	}
+}
+
+func TestWithPrecisionAndRounding(t *testing.T) {
+	// Test case 1: No options means no rounding
+	result, err := Add([][]float64{{1.125, 2.5}, {1, 1}})
+	if err != nil || !compareSlices(result, []float64{2.125, 3.5}, 0) {
+		t.Errorf("Expected [2.125 3.5], got %v (err %v)", result, err)
+	}
+
+	// Test case 2: Per-call rounding mode
+	result, err = Add([][]float64{{1.125, 2.5}, {1, 1}}, WithPrecision(2), WithRounding(RoundHalfEven))
+	if err != nil || !compareSlices(result, []float64{2.12, 3.5}, 0) {
+		t.Errorf("Expected [2.12 3.5], got %v (err %v)", result, err)
+	}
+
+	// Test case 3: The legacy wrapper matches the options form
+	legacy, _ := MeanArrays(2, []float64{1, 2}, []float64{2, 2})
+	modern, _ := Mean([][]float64{{1, 2}, {2, 2}}, WithPrecision(2))
+	if !compareSlices(legacy, modern, 0) {
+		t.Errorf("Expected %v, got %v", legacy, modern)
+	}
+}
+
+func TestWithOut(t *testing.T) {
+	// Test case 1: In-place addition into the first operand
+	a := []float64{1, 2, 3}
+	result, err := Add([][]float64{a, {10, 20, 30}}, WithOut(a))
+	if err != nil || &result[0] != &a[0] || !compareSlices(a, []float64{11, 22, 33}, 0) {
+		t.Errorf("Expected a to hold [11 22 33], got %v (err %v)", a, err)
+	}
+
+	// Test case 2: Destination of the wrong length
+	_, err = Multiply([][]float64{{1, 2}, {3, 4}}, WithOut(make([]float64, 3)))
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+
+	// Test case 3: A failed call leaves the destination untouched
+	dst := []float64{7, 7}
+	_, err = Divide([][]float64{{1, 2}, {1, 0}}, WithOut(dst))
+	if !errors.Is(err, ErrDivideByZero) || !compareSlices(dst, []float64{7, 7}, 0) {
+		t.Errorf("Expected dst to stay [7 7], got %v (err %v)", dst, err)
+	}
+
+	// Test case 4: Matrices use the destination as their row-major buffer
+	buf := make([]float64, 6)
+	transposed, err := Transpose([][]float64{{1, 2, 3}, {4, 5, 6}}, WithOut(buf))
+	if err != nil || len(transposed) != 3 || !compareSlices(buf, []float64{1, 4, 2, 5, 3, 6}, 0) {
+		t.Errorf("Expected buf [1 4 2 5 3 6], got %v (err %v)", buf, err)
+	}
+}
+
+func TestWithParallelism(t *testing.T) {
+	a := make([]float64, 10000)
+	b := make([]float64, 10000)
+	for i := range a {
+		a[i] = float64(i) * 0.5
+		b[i] = float64(i%7) + 1
+	}
+
+	// Test case 1: Parallel results match sequential ones
+	sequential, _ := Divide([][]float64{a, b}, WithPrecision(3))
+	parallel, err := Divide([][]float64{a, b}, WithPrecision(3), WithParallelism(4))
+	if err != nil || !compareSlices(sequential, parallel, 0) {
+		t.Errorf("Expected parallel results to match sequential ones (err %v)", err)
+	}
+
+	// Test case 2: Zero uses every available CPU
+	result, err := Variance([][]float64{a, b}, WithParallelism(0))
+	expected, _ := Variance([][]float64{a, b})
+	if err != nil || !compareSlices(result, expected, 0) {
+		t.Errorf("Expected parallel variance to match sequential one (err %v)", err)
+	}
+}
+
+func TestWithNaNPolicy(t *testing.T) {
+	nan := math.NaN()
+	arrays := [][]float64{{1, nan}, {3, 4}, {5, 6}}
+
+	// Test case 1: NaN propagates by default
+	result, err := Mean(arrays)
+	if err != nil || result[0] != 3 || !math.IsNaN(result[1]) {
+		t.Errorf("Expected [3 NaN], got %v (err %v)", result, err)
+	}
+
+	// Test case 2: NaNRaise reports the offending index
+	_, err = Mean(arrays, WithNaNPolicy(NaNRaise))
+	var valueErr *ValueError
+	if !errors.Is(err, ErrDomain) || !errors.As(err, &valueErr) || valueErr.Index != 1 {
+		t.Errorf("Expected ErrDomain at index 1, got %v", err)
+	}
+
+	// Test case 3: NaNOmit skips NaN values in statistics
+	result, err = Mean(arrays, WithNaNPolicy(NaNOmit))
+	if err != nil || !compareSlices(result, []float64{3, 5}, 0) {
+		t.Errorf("Expected [3 5], got %v (err %v)", result, err)
+	}
+	result, err = Percentile(50, [][]float64{{nan, 1, 3}}, WithNaNPolicy(NaNOmit))
+	if err != nil || !compareSlices(result, []float64{2}, 0) {
+		t.Errorf("Expected [2], got %v (err %v)", result, err)
+	}
+
+	// Test case 4: Positions holding only NaN give NaN
+	result, err = Max([][]float64{{nan, 1}, {nan, 2}}, WithNaNPolicy(NaNOmit))
+	if err != nil || !math.IsNaN(result[0]) || result[1] != 2 {
+		t.Errorf("Expected [NaN 2], got %v (err %v)", result, err)
+	}
+
+	// Test case 5: Minimum, maximum and range propagate NaN wherever it appears
+	withNaN := [][]float64{{1, nan}, {nan, 1}, {0, 0}}
+	for _, f := range []func([][]float64, ...Option) ([]float64, error){Min, Max, PeakToPeak} {
+		result, err = f(withNaN)
+		if err != nil || !compareSlices(result, []float64{nan, nan}, 0) {
+			t.Errorf("Expected [NaN NaN], got %v (err %v)", result, err)
+		}
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// Test case 1: The per-call logger receives the trace
+	_, err := Subtract([][]float64{{1.255}, {0}}, WithPrecision(2), WithLogger(l))
+	if err != nil || !strings.Contains(buf.String(), "op=Subtract") {
+		t.Errorf("Expected a trace for Subtract, got %q (err %v)", buf.String(), err)
+	}
+}
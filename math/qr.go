@@ -0,0 +1,287 @@
+package litearray
+
+import "math"
+
+// qrFactors holds a Householder QR factorization of an m×n matrix. The upper triangle of r holds
+// R; each reflection H_k = I − 2·v·vᵀ/(vᵀ·v) acts on rows k and below.
+type qrFactors struct {
+	m, n int
+	r    [][]float64
+	v    [][]float64 // Householder vectors, nil where no reflection was needed
+	vv   []float64   // vᵀ·v for each reflection
+	perm []int       // perm[j] is the column of the original matrix moved to column j
+}
+
+// LeastSquaresResult describes the least-squares solution of A·x ≈ b.
+type LeastSquaresResult struct {
+	// Solution minimizes ‖b − A·x‖. For rank-deficient A it is the basic solution, which sets
+	// the components of the columns left out by column pivoting to zero.
+	Solution []float64
+	// Residuals holds b − A·x for each row of A.
+	Residuals []float64
+	// SumSquaredResiduals is ‖b − A·x‖², the quantity NumPy's lstsq reports as residuals.
+	SumSquaredResiduals float64
+	// Rank is the numerical rank of A: the number of singular values above the tolerance, which
+	// defaults to max(m, n)·ε·σ_max and can be set with WithTolerance.
+	Rank int
+	// SingularValues holds the singular values of A in decreasing order, whose ratio shows how
+	// well-conditioned the fit is.
+	SingularValues []float64
+}
+
+// QR factorizes an m×n matrix as A = Q·R using Householder reflections, where Q is an m×m
+// orthogonal matrix and R is an m×n upper triangular matrix. The factors are rounded according
+// to opts.
+func QR(matrix [][]float64, opts ...Option) (q, r [][]float64, err error) {
+	q, r, _, err = qr("QR", matrix, false, opts)
+	return q, r, err
+}
+
+// QRPivoted factorizes an m×n matrix as A·P = Q·R using Householder reflections with column
+// pivoting, which moves the column with the largest remaining norm forward at each step so the
+// diagonal of R does not increase in magnitude. perm lists the columns of A in the order they
+// appear in A·P.
+func QRPivoted(matrix [][]float64, opts ...Option) (q, r [][]float64, perm []int, err error) {
+	return qr("QRPivoted", matrix, true, opts)
+}
+
+func qr(op string, matrix [][]float64, pivot bool, opts []Option) (q, r [][]float64, perm []int, err error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if _, err := checkRectangular(op, matrix); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return nil, nil, nil, err
+	}
+
+	f := factorQR(matrix, pivot)
+	qData := f.q()
+	q = rowsOf(qData, f.m, f.m)
+
+	// Keep only the upper triangle of R
+	rData := make([]float64, f.m*f.n)
+	r = rowsOf(rData, f.m, f.n)
+	for i := 0; i < f.m; i++ {
+		for j := i; j < f.n; j++ {
+			r[i][j] = f.r[i][j]
+		}
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), qData)
+	c.round(op, matrixShapes(matrix), rData)
+
+	return q, r, f.perm, nil
+}
+
+// LeastSquares finds x minimizing ‖b − A·x‖ for an m×n matrix A using a column-pivoted QR
+// factorization, which handles overdetermined, underdetermined and rank-deficient systems. The
+// solution, residuals and singular values are rounded according to opts, and WithOut receives
+// the solution.
+func LeastSquares(a [][]float64, b []float64, opts ...Option) (*LeastSquaresResult, error) {
+	const op = "LeastSquares"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := checkColumns(op, a)
+	if err != nil {
+		return nil, err
+	}
+	m := len(a)
+	if len(b) != m {
+		return nil, shapeError(op, [][]int{{m, n}, {len(b)}}, "right-hand side has length %d, expected %d", len(b), m)
+	}
+	if err := c.checkNaN(op, a...); err != nil {
+		return nil, err
+	}
+	if err := c.checkNaN(op, b); err != nil {
+		return nil, err
+	}
+
+	// The singular values decide the numerical rank
+	singular, err := singularValues(op, a)
+	if err != nil {
+		return nil, err
+	}
+	rank := c.rank(singular, m, n)
+
+	solution, err := c.result(op, n)
+	if err != nil {
+		return nil, err
+	}
+	clear(solution)
+
+	// Solve R₁₁·z = (Qᵀ·b)₁ for the leading rank columns and leave the others at zero
+	f := factorQR(a, true)
+	y := append([]float64(nil), b...)
+	f.applyQT(y)
+	for i := rank - 1; i >= 0; i-- {
+		sum := y[i]
+		for j := i + 1; j < rank; j++ {
+			sum -= f.r[i][j] * y[j]
+		}
+		y[i] = sum / f.r[i][i]
+	}
+	for j := 0; j < rank; j++ {
+		solution[f.perm[j]] = y[j]
+	}
+
+	// Report how far A·x is from b
+	residuals := make([]float64, m)
+	ssr := 0.0
+	for i, row := range a {
+		fitted := 0.0
+		for j, v := range row {
+			fitted += v * solution[j]
+		}
+		residuals[i] = b[i] - fitted
+		ssr += residuals[i] * residuals[i]
+	}
+
+	// Apply rounding if precision is non-negative
+	shapes := [][]int{{m, n}, {m}}
+	c.round(op, shapes, solution)
+	c.round(op, shapes, residuals)
+	c.round(op, shapes, singular)
+	total := []float64{ssr}
+	c.round(op, shapes, total)
+
+	return &LeastSquaresResult{
+		Solution:            solution,
+		Residuals:           residuals,
+		SumSquaredResiduals: total[0],
+		Rank:                rank,
+		SingularValues:      singular,
+	}, nil
+}
+
+// factorQR computes a Householder QR factorization of a rectangular matrix, choosing the column
+// with the largest remaining norm at each step when pivot is set.
+func factorQR(matrix [][]float64, pivot bool) *qrFactors {
+	m, n := len(matrix), len(matrix[0])
+	r := newMatrix(m, n)
+	for i := range matrix {
+		copy(r[i], matrix[i])
+	}
+	steps := min(m, n)
+	f := &qrFactors{m: m, n: n, r: r, v: make([][]float64, steps), vv: make([]float64, steps), perm: make([]int, n)}
+	for j := range f.perm {
+		f.perm[j] = j
+	}
+
+	for k := 0; k < steps; k++ {
+		// Move the column with the largest norm below row k forward
+		if pivot {
+			best, bestNorm := k, -1.0
+			for j := k; j < n; j++ {
+				norm := 0.0
+				for i := k; i < m; i++ {
+					norm += r[i][j] * r[i][j]
+				}
+				if norm > bestNorm {
+					best, bestNorm = j, norm
+				}
+			}
+			if best != k {
+				for i := 0; i < m; i++ {
+					r[i][k], r[i][best] = r[i][best], r[i][k]
+				}
+				f.perm[k], f.perm[best] = f.perm[best], f.perm[k]
+			}
+		}
+
+		// Build the reflection that maps column k onto a multiple of e_k, choosing the sign
+		// that avoids cancellation
+		alpha := 0.0
+		for i := k; i < m; i++ {
+			alpha += r[i][k] * r[i][k]
+		}
+		alpha = math.Sqrt(alpha)
+		if alpha == 0 {
+			continue
+		}
+		if r[k][k] > 0 {
+			alpha = -alpha
+		}
+		v := make([]float64, m-k)
+		for i := range v {
+			v[i] = r[k+i][k]
+		}
+		v[0] -= alpha
+		vv := 0.0
+		for _, x := range v {
+			vv += x * x
+		}
+		if vv == 0 {
+			continue
+		}
+		f.v[k], f.vv[k] = v, vv
+
+		// Apply it to the remaining columns
+		r[k][k] = alpha
+		for i := k + 1; i < m; i++ {
+			r[i][k] = 0
+		}
+		for j := k + 1; j < n; j++ {
+			dot := 0.0
+			for i, x := range v {
+				dot += x * r[k+i][j]
+			}
+			scale := 2 * dot / vv
+			for i, x := range v {
+				r[k+i][j] -= scale * x
+			}
+		}
+	}
+
+	return f
+}
+
+// applyQT overwrites y, of length m, with Qᵀ·y.
+func (f *qrFactors) applyQT(y []float64) {
+	for k, v := range f.v {
+		if v == nil {
+			continue
+		}
+		dot := 0.0
+		for i, x := range v {
+			dot += x * y[k+i]
+		}
+		scale := 2 * dot / f.vv[k]
+		for i, x := range v {
+			y[k+i] -= scale * x
+		}
+	}
+}
+
+// q forms the m×m orthogonal factor by applying the reflections to the identity in reverse order,
+// returning it as a row-major buffer.
+func (f *qrFactors) q() []float64 {
+	data := make([]float64, f.m*f.m)
+	q := rowsOf(data, f.m, f.m)
+	for i := range q {
+		q[i][i] = 1
+	}
+	for k := len(f.v) - 1; k >= 0; k-- {
+		v := f.v[k]
+		if v == nil {
+			continue
+		}
+		for j := 0; j < f.m; j++ {
+			dot := 0.0
+			for i, x := range v {
+				dot += x * q[k+i][j]
+			}
+			scale := 2 * dot / f.vv[k]
+			for i, x := range v {
+				q[k+i][j] -= scale * x
+			}
+		}
+	}
+	return data
+}
@@ -0,0 +1,119 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// transposeMatrix returns the transpose of a matrix for checking factorizations.
+func transposeMatrix(matrix [][]float64) [][]float64 {
+	result, _ := TransposeMatrix(-1, matrix)
+	return result
+}
+
+// identityMatrix returns the n×n identity matrix.
+func identityMatrix(n int) [][]float64 {
+	identity := newMatrix(n, n)
+	for i := range identity {
+		identity[i][i] = 1
+	}
+	return identity
+}
+
+func TestQR(t *testing.T) {
+	matrix := [][]float64{{12, -51, 4}, {6, 167, -68}, {-4, 24, -41}, {1, 2, 3}}
+
+	// Test case 1: Q is orthogonal, R is upper triangular and Q·R = A
+	q, r, err := QR(matrix)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !compareMatrices(multiplyMatrices(transposeMatrix(q), q), identityMatrix(4), 1e-12) {
+		t.Errorf("Expected QᵀQ = I, got %v", multiplyMatrices(transposeMatrix(q), q))
+	}
+	if !compareMatrices(multiplyMatrices(q, r), matrix, 1e-10) {
+		t.Errorf("Expected Q·R = A, got %v", multiplyMatrices(q, r))
+	}
+	for i := range r {
+		for j := 0; j < i && j < len(r[i]); j++ {
+			if r[i][j] != 0 {
+				t.Errorf("Expected R to be upper triangular, got %v", r)
+			}
+		}
+	}
+
+	// Test case 2: Column pivoting orders the diagonal of R by magnitude
+	q, r, perm, err := QRPivoted(matrix)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	permuted := newMatrix(4, 3)
+	for i := range matrix {
+		for j, col := range perm {
+			permuted[i][j] = matrix[i][col]
+		}
+	}
+	if !compareMatrices(multiplyMatrices(q, r), permuted, 1e-10) {
+		t.Errorf("Expected Q·R = A·P, got %v", multiplyMatrices(q, r))
+	}
+	if perm[0] != 1 || math.Abs(r[0][0]) < math.Abs(r[1][1]) || math.Abs(r[1][1]) < math.Abs(r[2][2]) {
+		t.Errorf("Expected a non-increasing diagonal starting with column 1, got perm %v and R %v", perm, r)
+	}
+
+	// Test case 3: Factors follow the precision option
+	_, r, err = QR([][]float64{{3, 1}, {4, 2}}, WithPrecision(2))
+	if err != nil || r[0][0] != -5 || r[0][1] != -2.2 {
+		t.Errorf("Expected R to start [-5 -2.2], got %v (err %v)", r, err)
+	}
+
+	// Test case 4: Ragged rows
+	_, _, err = QR([][]float64{{1, 2}, {3}})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// Test case 1: Exact fit of a line y = 1 + 2x
+	a := [][]float64{{1, 0}, {1, 1}, {1, 2}, {1, 3}}
+	fit, err := LeastSquares(a, []float64{1, 3, 5, 7})
+	if err != nil || !compareSlices(fit.Solution, []float64{1, 2}, 1e-12) || fit.Rank != 2 || fit.SumSquaredResiduals > 1e-20 {
+		t.Errorf("Expected solution [1 2] with rank 2, got %+v (err %v)", fit, err)
+	}
+
+	// Test case 2: Overdetermined fit matches the normal equations
+	fit, err = LeastSquares(a, []float64{1, 2, 2, 4}, WithPrecision(6))
+	if err != nil || !compareSlices(fit.Solution, []float64{0.9, 0.9}, 0) {
+		t.Errorf("Expected solution [0.9 0.9], got %+v (err %v)", fit, err)
+	}
+	if !compareSlices(fit.Residuals, []float64{0.1, 0.2, -0.7, 0.4}, 0) || fit.SumSquaredResiduals != 0.7 {
+		t.Errorf("Expected residuals [0.1 0.2 -0.7 0.4] summing to 0.7, got %+v", fit)
+	}
+	if len(fit.SingularValues) != 2 || fit.SingularValues[0] < fit.SingularValues[1] {
+		t.Errorf("Expected two decreasing singular values, got %v", fit.SingularValues)
+	}
+
+	// Test case 3: Rank-deficient systems
+	fit, err = LeastSquares([][]float64{{1, 2}, {2, 4}, {3, 6}}, []float64{1, 2, 3})
+	if err != nil || fit.Rank != 1 || fit.SumSquaredResiduals > 1e-20 {
+		t.Errorf("Expected an exact rank 1 fit, got %+v (err %v)", fit, err)
+	}
+
+	// Test case 4: Underdetermined systems
+	fit, err = LeastSquares([][]float64{{1, 1, 1}}, []float64{3})
+	if err != nil || fit.Rank != 1 || math.Abs(fit.Residuals[0]) > 1e-12 {
+		t.Errorf("Expected an exact rank 1 fit, got %+v (err %v)", fit, err)
+	}
+
+	// Test case 5: Mismatched right-hand side
+	_, err = LeastSquares(a, []float64{1, 2})
+	if !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	// Test case 6: A matrix without columns
+	_, err = LeastSquares([][]float64{{}, {}}, []float64{1, 2})
+	if !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+}
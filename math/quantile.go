@@ -0,0 +1,307 @@
+package litearray
+
+import "math"
+
+// QuantileMethod selects how a quantile is estimated from the order statistics x₁ ≤ … ≤ xₙ of a
+// sample. The nine methods of Hyndman and Fan (1996) are available under the names NumPy uses,
+// together with NumPy's four discontinuous variants of the default method.
+type QuantileMethod int
+
+const (
+	// QuantileLinear interpolates linearly at position (n − 1)·q from zero. It is Hyndman–Fan
+	// type 7, the default of NumPy, R and spreadsheet PERCENTILE functions, and the default here.
+	QuantileLinear QuantileMethod = iota
+	// QuantileInvertedCDF takes the smallest x whose empirical CDF reaches q (type 1).
+	QuantileInvertedCDF
+	// QuantileAveragedInvertedCDF is QuantileInvertedCDF, averaging at discontinuities (type 2).
+	QuantileAveragedInvertedCDF
+	// QuantileClosestObservation takes the observation nearest to n·q, preferring even ranks
+	// on ties (type 3, SAS definition 2).
+	QuantileClosestObservation
+	// QuantileInterpolatedInvertedCDF interpolates the empirical CDF linearly (type 4).
+	QuantileInterpolatedInvertedCDF
+	// QuantileHazen interpolates at position n·q + 1/2 (type 5), popular in hydrology.
+	QuantileHazen
+	// QuantileWeibull interpolates at position (n + 1)·q (type 6), as Minitab and SPSS do.
+	QuantileWeibull
+	// QuantileMedianUnbiased interpolates at position (n + 1/3)·q + 1/3 (type 8), approximately
+	// median-unbiased whatever the distribution. Hyndman and Fan recommend it.
+	QuantileMedianUnbiased
+	// QuantileNormalUnbiased interpolates at position (n + 1/4)·q + 3/8 (type 9), approximately
+	// unbiased for normally distributed data.
+	QuantileNormalUnbiased
+	// QuantileLower takes the order statistic just below the QuantileLinear position.
+	QuantileLower
+	// QuantileHigher takes the order statistic just above the QuantileLinear position.
+	QuantileHigher
+	// QuantileNearest takes the order statistic nearest to the QuantileLinear position, the
+	// even one on ties.
+	QuantileNearest
+	// QuantileMidpoint averages QuantileLower and QuantileHigher.
+	QuantileMidpoint
+)
+
+// maxLopsided is the number of partitions that leave more than three quarters of the range before
+// selectKth switches to median-of-medians pivots.
+const maxLopsided = 4
+
+// Quantiles calculates the quantiles q of values, each between 0 and 1, in one pass of selection
+// rather than a full sort. values is not modified. WithQuantileMethod selects the estimator,
+// QuantileLinear by default. NaN values give NaN quantiles unless WithNaNPolicy says otherwise.
+//
+// WithWeights gives one weight per value. Weighted quantiles invert the weighted empirical CDF,
+// so only QuantileInvertedCDF and QuantileAveragedInvertedCDF apply; the latter is the default
+// and gives the usual weighted median. They sort a copy of the values, taking O(N log N) time.
+func Quantiles(values, q []float64, opts ...Option) ([]float64, error) {
+	const op = "Quantiles"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, emptyError(op, shapesOf(values, q), "values cannot be empty")
+	}
+	if err := checkQuantiles(op, q); err != nil {
+		return nil, err
+	}
+	if c.weights != nil {
+		if err := c.checkQuantileWeights(op, len(values)); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.checkNaN(op, values); err != nil {
+		return nil, err
+	}
+
+	result, err := c.result(op, len(q))
+	if err != nil {
+		return nil, err
+	}
+	if c.weights != nil {
+		c.weightedQuantiles(result, values, c.weights, q)
+	} else {
+		c.quantiles(result, append([]float64(nil), values...), q)
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, shapesOf(values, q), result)
+
+	return result, nil
+}
+
+// quantiles writes the quantiles q of data to dst. data must be a private copy, as it is
+// reordered; its NaN values are dropped under NaNOmit and make every quantile NaN otherwise.
+func (c *config) quantiles(dst, data, q []float64) {
+	data = c.dropNaN(data)
+	if data == nil {
+		for i := range dst {
+			dst[i] = math.NaN()
+		}
+		return
+	}
+
+	// Find the order statistics each quantile needs
+	n := len(data)
+	lows, highs, weights := make([]int, len(q)), make([]int, len(q)), make([]float64, len(q))
+	needed := make([]bool, n)
+	for i, p := range q {
+		lows[i], highs[i], weights[i] = quantilePosition(c.quantileMethod, n, p)
+		needed[lows[i]], needed[highs[i]] = true, true
+	}
+
+	// Select them in increasing order, each within the part left above the previous one
+	start := 0
+	for k, ok := range needed {
+		if ok {
+			selectKth(data[start:], k-start)
+			start = k + 1
+		}
+	}
+
+	for i := range q {
+		dst[i] = interpolate(data[lows[i]], data[highs[i]], weights[i])
+	}
+}
+
+// quantile returns the q-quantile of data, which must be a private copy.
+func (c *config) quantile(data []float64, q float64) float64 {
+	result := []float64{0}
+	c.quantiles(result, data, []float64{q})
+	return result[0]
+}
+
+// dropNaN removes the NaN values of data in place under NaNOmit. It returns nil when nothing is
+// left, or when data holds a NaN that should propagate.
+func (c *config) dropNaN(data []float64) []float64 {
+	kept := data[:0]
+	for _, v := range data {
+		if !math.IsNaN(v) {
+			kept = append(kept, v)
+		} else if !c.omitNaN() {
+			return nil
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// quantilePosition returns the zero-based order statistics lo ≤ hi and the weight g for which
+// (1 − g)·x[lo] + g·x[hi] is the q-quantile of a sorted sample of size n.
+func quantilePosition(method QuantileMethod, n int, q float64) (lo, hi int, g float64) {
+	clamp := func(i int) int { return min(max(i, 0), n-1) }
+	at := func(i int) (int, int, float64) { return clamp(i), clamp(i), 0 }
+	nq := float64(n) * q
+
+	// The continuous methods interpolate at one-based position n·q + α + q·(1 − α − β)
+	var alpha, beta float64
+	switch method {
+	case QuantileInvertedCDF:
+		return at(int(math.Ceil(nq)) - 1)
+	case QuantileAveragedInvertedCDF:
+		j := math.Floor(nq)
+		if nq > j {
+			return at(int(j))
+		}
+		return clamp(int(j) - 1), clamp(int(j)), 0.5
+	case QuantileClosestObservation:
+		h := nq - 0.5
+		j := math.Floor(h)
+		if h == j && int(j)%2 == 0 {
+			return at(int(j) - 1)
+		}
+		return at(int(j))
+	case QuantileLower, QuantileHigher, QuantileNearest, QuantileMidpoint:
+		h := float64(n-1) * q
+		switch method {
+		case QuantileLower:
+			return at(int(math.Floor(h)))
+		case QuantileHigher:
+			return at(int(math.Ceil(h)))
+		case QuantileNearest:
+			return at(int(math.RoundToEven(h)))
+		}
+		return int(math.Floor(h)), int(math.Ceil(h)), 0.5
+	case QuantileInterpolatedInvertedCDF:
+		alpha, beta = 0, 1
+	case QuantileHazen:
+		alpha, beta = 0.5, 0.5
+	case QuantileWeibull:
+		alpha, beta = 0, 0
+	case QuantileMedianUnbiased:
+		alpha, beta = 1.0/3, 1.0/3
+	case QuantileNormalUnbiased:
+		alpha, beta = 3.0/8, 3.0/8
+	default:
+		alpha, beta = 1, 1
+	}
+	h := nq + alpha + q*(1-alpha-beta)
+	j := math.Floor(h)
+	switch {
+	case j < 1:
+		return at(0)
+	case j >= float64(n):
+		return at(n - 1)
+	}
+	return int(j) - 1, int(j), h - j
+}
+
+// interpolate returns (1 − g)·a + g·b, exactly a or b at the ends so that infinite values survive.
+func interpolate(a, b, g float64) float64 {
+	switch {
+	case g == 0 || a == b:
+		return a
+	case g == 1:
+		return b
+	}
+	return a + g*(b-a)
+}
+
+// selectKth reorders data so that data[k] holds the value it would hold if data were sorted, with
+// no larger values before it and no smaller ones after. It runs quickselect with median-of-three
+// pivots, switching to median-of-medians pivots after a few lopsided partitions, which keeps the
+// worst case linear. data must not hold NaN.
+func selectKth(data []float64, k int) {
+	lo, hi := 0, len(data)-1
+	lopsided := 0
+	for hi-lo >= 16 {
+		var pivot float64
+		if lopsided < maxLopsided {
+			pivot = medianOfThree(data[lo], data[lo+(hi-lo)/2], data[hi])
+		} else {
+			pivot = medianOfMedians(data[lo : hi+1])
+		}
+
+		// Partition into values below, equal to and above the pivot
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch v := data[i]; {
+			case v < pivot:
+				data[lt], data[i] = data[i], data[lt]
+				lt++
+				i++
+			case v > pivot:
+				data[gt], data[i] = data[i], data[gt]
+				gt--
+			default:
+				i++
+			}
+		}
+
+		size := hi - lo + 1
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return
+		}
+		if 4*(hi-lo+1) > 3*size {
+			lopsided++
+		}
+	}
+
+	// Finish small ranges by insertion sort
+	for i := lo + 1; i <= hi; i++ {
+		for j := i; j > lo && data[j] < data[j-1]; j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// medianOfThree returns the middle value of a, b and c.
+func medianOfThree(a, b, c float64) float64 {
+	if a > b {
+		a, b = b, a
+	}
+	if b > c {
+		b = c
+	}
+	return max(a, b)
+}
+
+// medianOfMedians returns the median of the medians of groups of five values, which has at least
+// 30% of data on either side of it.
+func medianOfMedians(data []float64) float64 {
+	medians := make([]float64, 0, (len(data)+4)/5)
+	group := make([]float64, 0, 5)
+	for i := 0; i < len(data); i += 5 {
+		group = append(group[:0], data[i:min(i+5, len(data))]...)
+		selectKth(group, len(group)/2)
+		medians = append(medians, group[len(group)/2])
+	}
+	selectKth(medians, len(medians)/2)
+	return medians[len(medians)/2]
+}
+
+// checkQuantiles checks that every quantile lies between 0 and 1.
+func checkQuantiles(op string, q []float64) error {
+	for i, p := range q {
+		if !(p >= 0 && p <= 1) {
+			return argumentError(op, "quantile at index %d must be between 0 and 1, got %v", i, p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestQuantileMethods(t *testing.T) {
+	values := []float64{7, 1, 3, 10, 4, 2, 9}
+
+	// Test case 1: Every method at the lower quartile and the extremes, against hand computations
+	expected := map[QuantileMethod]float64{
+		QuantileLinear:                  2.5,
+		QuantileInvertedCDF:             2,
+		QuantileAveragedInvertedCDF:     2,
+		QuantileClosestObservation:      2,
+		QuantileInterpolatedInvertedCDF: 1.75,
+		QuantileHazen:                   2.25,
+		QuantileWeibull:                 2,
+		QuantileMedianUnbiased:          2 + 1.0/6,
+		QuantileNormalUnbiased:          2.1875,
+		QuantileLower:                   2,
+		QuantileHigher:                  3,
+		QuantileNearest:                 3,
+		QuantileMidpoint:                2.5,
+	}
+	for method, quartile := range expected {
+		result, err := Quantiles(values, []float64{0, 0.25, 1}, WithQuantileMethod(method))
+		if err != nil || !compareSlices(result, []float64{1, quartile, 10}, 1e-12) {
+			t.Errorf("Method %d: expected [1 %v 10], got %v (err %v)", method, quartile, result, err)
+		}
+	}
+
+	// Test case 2: Medians of an even count
+	for method, want := range map[QuantileMethod]float64{QuantileLinear: 2.5, QuantileInvertedCDF: 2, QuantileAveragedInvertedCDF: 2.5, QuantileLower: 2, QuantileHigher: 3} {
+		result, _ := Quantiles([]float64{4, 1, 3, 2}, []float64{0.5}, WithQuantileMethod(method))
+		if !compareSlices(result, []float64{want}, 0) {
+			t.Errorf("Method %d: expected %v, got %v", method, want, result)
+		}
+	}
+
+	// Test case 3: The input is left alone
+	if !compareSlices(values, []float64{7, 1, 3, 10, 4, 2, 9}, 0) {
+		t.Errorf("Expected the input to be left alone, got %v", values)
+	}
+
+	// Test case 4: NaN handling and invalid input
+	result, _ := Quantiles([]float64{1, math.NaN(), 3}, []float64{0.5})
+	if !math.IsNaN(result[0]) {
+		t.Errorf("Expected NaN, got %v", result)
+	}
+	result, _ = Quantiles([]float64{1, math.NaN(), 3}, []float64{0.5}, WithNaNPolicy(NaNOmit))
+	if !compareSlices(result, []float64{2}, 0) {
+		t.Errorf("Expected [2], got %v", result)
+	}
+	if _, err := Quantiles(values, []float64{1.5}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := Quantiles(values, []float64{0.5}, WithQuantileMethod(QuantileMidpoint+1)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := Quantiles(nil, []float64{0.5}); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestQuantileSelection(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	q := []float64{0, 0.01, 0.3, 0.5, 0.5, 0.77, 0.999, 1}
+
+	// Test case 1: Random, sorted, reversed, few distinct and organ-pipe inputs match a full sort
+	n := 10001
+	inputs := map[string][]float64{"random": {}, "sorted": {}, "reversed": {}, "duplicates": {}, "organ pipe": {}}
+	for i := 0; i < n; i++ {
+		inputs["random"] = append(inputs["random"], rng.NormFloat64())
+		inputs["sorted"] = append(inputs["sorted"], float64(i))
+		inputs["reversed"] = append(inputs["reversed"], float64(n-i))
+		inputs["duplicates"] = append(inputs["duplicates"], float64(rng.Intn(3)))
+		inputs["organ pipe"] = append(inputs["organ pipe"], float64(min(i, n-i)))
+	}
+	for name, values := range inputs {
+		result, err := Quantiles(values, q)
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		for i, p := range q {
+			h := p * float64(n-1)
+			lo, hi := int(math.Floor(h)), int(math.Ceil(h))
+			want := sorted[lo] + (h-float64(lo))*(sorted[hi]-sorted[lo])
+			if err != nil || math.Abs(result[i]-want) > 1e-12 {
+				t.Errorf("%s: expected quantile %v to be %v, got %v (err %v)", name, p, want, result[i], err)
+			}
+		}
+	}
+}
+
+func TestMedianAndPercentileDoNotMutate(t *testing.T) {
+	a := []float64{9, 1, 5}
+	b := []float64{2, 8, 3}
+
+	// Test case 1: The median is taken over the pooled data, not the element-wise means
+	result, err := Median([][]float64{a, b})
+	if err != nil || !compareSlices(result, []float64{4}, 0) {
+		t.Errorf("Expected [4], got %v (err %v)", result, err)
+	}
+	result, _ = Median([][]float64{a, b}, WithQuantileMethod(QuantileHigher))
+	if !compareSlices(result, []float64{5}, 0) {
+		t.Errorf("Expected [5], got %v", result)
+	}
+
+	// Test case 2: Percentile leaves its input unsorted
+	result, err = Percentile(50, [][]float64{a, b})
+	if err != nil || !compareSlices(result, []float64{5, 3}, 0) {
+		t.Errorf("Expected [5 3], got %v (err %v)", result, err)
+	}
+	if !compareSlices(a, []float64{9, 1, 5}, 0) || !compareSlices(b, []float64{2, 8, 3}, 0) {
+		t.Errorf("Expected the inputs to be left alone, got %v and %v", a, b)
+	}
+}
@@ -0,0 +1,323 @@
+package litearray
+
+import "math"
+
+// AllAxes can be passed as the axis of a reduction to reduce over every element of the array.
+const AllAxes = math.MinInt
+
+// Sum calculates the sum along an axis and supports optional rounding to a specified precision.
+// Negative axes count from the last axis; pass AllAxes to sum every element. When keepDims is
+// true the reduced axis is kept with length one so the result broadcasts against the input.
+// It is equivalent to SumWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) Sum(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.SumWith(axis, keepDims, WithPrecision(precision))
+}
+
+// SumWith calculates the sum along an axis, configured by opts. Under NaNOmit NaN values are
+// skipped, so a lane of NaN sums to zero. With WithOut the result is written to the given
+// buffer, which must hold one element per lane.
+func (a *Array) SumWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.reduce("Array.Sum", axis, keepDims, false, opts, func(c *config, lane []float64) float64 {
+		sum := 0.0
+		for _, v := range lane {
+			sum += v
+		}
+		return sum
+	})
+}
+
+// Prod calculates the product along an axis and supports optional rounding to a specified precision.
+// It is equivalent to ProdWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) Prod(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.ProdWith(axis, keepDims, WithPrecision(precision))
+}
+
+// ProdWith calculates the product along an axis, configured by opts like SumWith.
+func (a *Array) ProdWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.reduce("Array.Prod", axis, keepDims, false, opts, func(c *config, lane []float64) float64 {
+		prod := 1.0
+		for _, v := range lane {
+			prod *= v
+		}
+		return prod
+	})
+}
+
+// Mean calculates the mean along an axis and supports optional rounding to a specified precision.
+// It is equivalent to MeanWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) Mean(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.MeanWith(axis, keepDims, WithPrecision(precision))
+}
+
+// MeanWith calculates the mean along an axis, configured by opts. Under NaNOmit each mean is
+// taken over the values that are not NaN, and lanes with none give NaN.
+func (a *Array) MeanWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.reduce("Array.Mean", axis, keepDims, true, opts, func(c *config, lane []float64) float64 {
+		return laneMean(lane)
+	})
+}
+
+// Variance calculates the population variance along an axis and supports optional rounding to a specified precision.
+// It is equivalent to VarianceWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) Variance(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.VarianceWith(axis, keepDims, WithPrecision(precision))
+}
+
+// VarianceWith calculates the variance along an axis, configured by opts like MeanWith. It is
+// the population variance unless WithDDOF sets the degrees of freedom; lanes with no more values
+// than ddof give NaN.
+func (a *Array) VarianceWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.reduce("Array.Variance", axis, keepDims, true, opts, func(c *config, lane []float64) float64 {
+		return laneVariance(lane, c.ddof)
+	})
+}
+
+// StandardDeviation calculates the population standard deviation along an axis and supports optional rounding to a specified precision.
+// It is equivalent to StandardDeviationWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) StandardDeviation(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.StandardDeviationWith(axis, keepDims, WithPrecision(precision))
+}
+
+// StandardDeviationWith calculates the standard deviation along an axis, configured by opts like
+// VarianceWith.
+func (a *Array) StandardDeviationWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.reduce("Array.StandardDeviation", axis, keepDims, true, opts, func(c *config, lane []float64) float64 {
+		return math.Sqrt(laneVariance(lane, c.ddof))
+	})
+}
+
+// Min finds the minimum along an axis and supports optional rounding to a specified precision.
+// It is equivalent to MinWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) Min(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.MinWith(axis, keepDims, WithPrecision(precision))
+}
+
+// MinWith finds the minimum along an axis, configured by opts like MeanWith.
+func (a *Array) MinWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.reduce("Array.Min", axis, keepDims, true, opts, func(c *config, lane []float64) float64 {
+		return lane[laneArgMin(lane)]
+	})
+}
+
+// Max finds the maximum along an axis and supports optional rounding to a specified precision.
+// It is equivalent to MaxWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) Max(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.MaxWith(axis, keepDims, WithPrecision(precision))
+}
+
+// MaxWith finds the maximum along an axis, configured by opts like MeanWith.
+func (a *Array) MaxWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.reduce("Array.Max", axis, keepDims, true, opts, func(c *config, lane []float64) float64 {
+		return lane[laneArgMax(lane)]
+	})
+}
+
+// Range calculates the range (max - min) along an axis and supports optional rounding to a specified precision.
+// It is equivalent to RangeWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) Range(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.RangeWith(axis, keepDims, WithPrecision(precision))
+}
+
+// RangeWith calculates the range (max - min) along an axis, configured by opts like MeanWith.
+func (a *Array) RangeWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.reduce("Array.Range", axis, keepDims, true, opts, func(c *config, lane []float64) float64 {
+		return lane[laneArgMax(lane)] - lane[laneArgMin(lane)]
+	})
+}
+
+// ArgMin returns the index of the first minimum along an axis, or of the first NaN if there is
+// one. With AllAxes the index is into the row-major flattening of the array.
+func (a *Array) ArgMin(axis int, keepDims bool) (*Array, error) {
+	return a.reduce("Array.ArgMin", axis, keepDims, true, nil, func(c *config, lane []float64) float64 {
+		return float64(laneArgMin(lane))
+	})
+}
+
+// ArgMax returns the index of the first maximum along an axis, or of the first NaN if there is
+// one. With AllAxes the index is into the row-major flattening of the array.
+func (a *Array) ArgMax(axis int, keepDims bool) (*Array, error) {
+	return a.reduce("Array.ArgMax", axis, keepDims, true, nil, func(c *config, lane []float64) float64 {
+		return float64(laneArgMax(lane))
+	})
+}
+
+// Median calculates the median along an axis and supports optional rounding to a specified precision.
+// It is equivalent to MedianWith(axis, keepDims, WithPrecision(precision)).
+func (a *Array) Median(precision int, axis int, keepDims bool) (*Array, error) {
+	return a.MedianWith(axis, keepDims, WithPrecision(precision))
+}
+
+// MedianWith calculates the median along an axis, configured by opts like PercentileWith.
+func (a *Array) MedianWith(axis int, keepDims bool, opts ...Option) (*Array, error) {
+	return a.PercentileWith(50, axis, keepDims, opts...)
+}
+
+// Percentile calculates the given percentile along an axis, interpolating linearly between
+// the closest ranks like PercentileArrays. The array itself is never reordered.
+// It is equivalent to PercentileWith(percentile, axis, keepDims, WithPrecision(precision)).
+func (a *Array) Percentile(precision int, percentile float64, axis int, keepDims bool) (*Array, error) {
+	return a.PercentileWith(percentile, axis, keepDims, WithPrecision(precision))
+}
+
+// PercentileWith calculates the given percentile along an axis, configured by opts.
+// WithQuantileMethod selects the estimator, QuantileLinear by default, and under NaNOmit each
+// percentile is taken over the values that are not NaN.
+func (a *Array) PercentileWith(percentile float64, axis int, keepDims bool, opts ...Option) (*Array, error) {
+	if percentile < 0 || percentile > 100 {
+		return nil, argumentError("Array.Percentile", "percentile must be between 0 and 100")
+	}
+	return a.reduce("Array.Percentile", axis, keepDims, true, opts, func(c *config, lane []float64) float64 {
+		// Lanes are private copies, so selection can reorder them
+		return c.quantile(lane, percentile/100)
+	})
+}
+
+// reduce applies fn to every lane of a along axis, or to all of its elements for AllAxes, and
+// collects the results in an array without that axis. Each lane passed to fn is a fresh copy,
+// without its NaN values under NaNOmit. When nonEmpty is true, reducing an axis of length zero is
+// an error, and lanes left empty by NaNOmit give NaN.
+func (a *Array) reduce(op string, axis int, keepDims bool, nonEmpty bool, opts []Option, fn func(c *config, lane []float64) float64) (*Array, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ndim := len(a.shape)
+	var lanes [][]float64
+	var shape []int
+
+	if axis == AllAxes {
+		// The whole array forms a single lane
+		lanes = [][]float64{a.Data()}
+		if keepDims {
+			shape = make([]int, ndim)
+			for i := range shape {
+				shape[i] = 1
+			}
+		}
+	} else {
+		// Resolve negative axes against the number of dimensions
+		requested := axis
+		if axis < 0 {
+			axis += ndim
+		}
+		if axis < 0 || axis >= ndim {
+			return nil, argumentError(op, "axis %d is out of bounds for %d-dimensional array", requested, ndim)
+		}
+
+		// Move the reduced axis last so each lane is contiguous in the row-major data
+		perm := make([]int, 0, ndim)
+		for i := 0; i < ndim; i++ {
+			if i != axis {
+				perm = append(perm, i)
+				shape = append(shape, a.shape[i])
+			}
+		}
+		perm = append(perm, axis)
+		moved, err := a.Transpose(perm...)
+		if err != nil {
+			return nil, err
+		}
+
+		data := moved.Data()
+		width := a.shape[axis]
+		count, _ := shapeSize(shape)
+		lanes = make([][]float64, count)
+		for i := range lanes {
+			lanes[i] = data[i*width : (i+1)*width : (i+1)*width]
+		}
+
+		if keepDims {
+			shape = a.Shape()
+			shape[axis] = 1
+		}
+	}
+	if nonEmpty && len(lanes) > 0 && len(lanes[0]) == 0 {
+		return nil, emptyError(op, [][]int{a.shape}, "cannot reduce an empty axis of array with shape %v", a.shape)
+	}
+	if err := c.checkNaN(op, lanes...); err != nil {
+		return nil, err
+	}
+
+	// Reduce each lane and round the results
+	result, err := c.result(op, len(lanes))
+	if err != nil {
+		return nil, err
+	}
+	// Hand each goroutine lanes holding at least minChunk elements between them
+	width := 1
+	if len(lanes) > 0 {
+		width = max(width, len(lanes[0]))
+	}
+	c.parallelChunks(len(lanes), max(1, minChunk/width), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			lane := lanes[i]
+			if c.omitNaN() {
+				lane = c.dropNaN(lane)
+				if lane == nil && nonEmpty {
+					result[i] = math.NaN()
+					continue
+				}
+			}
+			result[i] = fn(c, lane)
+		}
+	})
+	c.round(op, [][]int{a.shape}, result)
+
+	return wrapArray(result, shape), nil
+}
+
+// laneMean calculates the mean of a non-empty lane.
+func laneMean(lane []float64) float64 {
+	sum := 0.0
+	for _, v := range lane {
+		sum += v
+	}
+	return sum / float64(len(lane))
+}
+
+// laneVariance calculates the variance of a non-empty lane, dividing by len(lane) − ddof. It uses
+// Welford's algorithm, which stays accurate for values far from zero where the textbook formula
+// E[x²] − E[x]² cancels catastrophically. Lanes with no more than ddof values give NaN.
+func laneVariance(lane []float64, ddof int) float64 {
+	if len(lane) <= ddof {
+		return math.NaN()
+	}
+	mean, squares := 0.0, 0.0
+	for i, v := range lane {
+		delta := v - mean
+		mean += delta / float64(i+1)
+		squares += delta * (v - mean)
+	}
+	return squares / float64(len(lane)-ddof)
+}
+
+// laneArgMin returns the index of the first minimum of a non-empty lane, or of its first NaN
+// so that NaN propagates as in NumPy.
+func laneArgMin(lane []float64) int {
+	best := 0
+	for i, v := range lane {
+		if math.IsNaN(v) {
+			return i
+		}
+		if v < lane[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// laneArgMax returns the index of the first maximum of a non-empty lane, or of its first NaN
+// so that NaN propagates as in NumPy.
+func laneArgMax(lane []float64) int {
+	best := 0
+	for i, v := range lane {
+		if math.IsNaN(v) {
+			return i
+		}
+		if v > lane[best] {
+			best = i
+		}
+	}
+	return best
+}
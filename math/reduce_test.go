@@ -0,0 +1,186 @@
+package litearray
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArraySum(t *testing.T) {
+	arr, _ := NewArray([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+
+	// Test case 1: Sum along the first axis
+	result, err := arr.Sum(2, 0, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(result.Shape(), []int{3}) || !compareSlices(result.Data(), []float64{5, 7, 9}, 0.0001) {
+		t.Errorf("Expected [5 7 9], got %v", result)
+	}
+
+	// Test case 2: Negative axis with keepDims
+	result, err = arr.Sum(2, -1, true)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(result.Shape(), []int{2, 1}) || !compareSlices(result.Data(), []float64{6, 15}, 0.0001) {
+		t.Errorf("Expected [[6] [15]], got %v", result)
+	}
+
+	// Test case 3: All axes produces a zero-dimensional array
+	result, err = arr.Sum(2, AllAxes, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if result.Ndim() != 0 || !compareSlices(result.Data(), []float64{21}, 0.0001) {
+		t.Errorf("Expected scalar 21, got %v with shape %v", result, result.Shape())
+	}
+
+	// Test case 4: Axis out of range
+	_, err = arr.Sum(2, 2, false)
+	if err == nil {
+		t.Error("Expected an error for an out of range axis, got none")
+	}
+}
+
+func TestArrayStatistics(t *testing.T) {
+	// A 2x2x3 array so reductions run over a middle axis
+	arr, _ := NewArray([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 2, 2, 3)
+
+	// Test case 1: Mean over the middle axis
+	result, err := arr.Mean(2, 1, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(result.Shape(), []int{2, 3}) || !compareSlices(result.Data(), []float64{2.5, 3.5, 4.5, 8.5, 9.5, 10.5}, 0.0001) {
+		t.Errorf("Expected [[2.5 3.5 4.5] [8.5 9.5 10.5]], got %v", result)
+	}
+
+	// Test case 2: Variance and standard deviation over the last axis
+	result, err = arr.Variance(2, 2, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result.Data(), []float64{0.67, 0.67, 0.67, 0.67}, 0.0001) {
+		t.Errorf("Expected [[0.67 0.67] [0.67 0.67]], got %v", result)
+	}
+	result, err = arr.StandardDeviation(-1, AllAxes, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result.Data(), []float64{3.452052529534663}, 0.0001) {
+		t.Errorf("Expected 3.452052529534663, got %v", result)
+	}
+
+	// Test case 3: Product, minimum, maximum and range
+	prod, _ := arr.Prod(2, 0, false)
+	minimum, _ := arr.Min(2, 0, false)
+	maximum, _ := arr.Max(2, 0, false)
+	spread, _ := arr.Range(2, 0, false)
+	if !compareSlices(prod.Data(), []float64{7, 16, 27, 40, 55, 72}, 0.0001) ||
+		!compareSlices(minimum.Data(), []float64{1, 2, 3, 4, 5, 6}, 0.0001) ||
+		!compareSlices(maximum.Data(), []float64{7, 8, 9, 10, 11, 12}, 0.0001) ||
+		!compareSlices(spread.Data(), []float64{6, 6, 6, 6, 6, 6}, 0.0001) {
+		t.Errorf("Unexpected results: prod %v, min %v, max %v, range %v", prod, minimum, maximum, spread)
+	}
+	// Test case 4: Sample variance and standard deviation with WithDDOF
+	result, err = arr.VarianceWith(2, false, WithDDOF(1))
+	if err != nil || !compareSlices(result.Data(), []float64{1, 1, 1, 1}, 1e-12) {
+		t.Errorf("Expected [[1 1] [1 1]], got %v (err %v)", result, err)
+	}
+	result, _ = arr.StandardDeviationWith(AllAxes, false, WithDDOF(1))
+	if !compareSlices(result.Data(), []float64{math.Sqrt(13)}, 1e-12) {
+		t.Errorf("Expected √13, got %v", result)
+	}
+	result, _ = arr.VarianceWith(2, false, WithDDOF(3))
+	if !math.IsNaN(result.Data()[0]) {
+		t.Errorf("Expected NaN without degrees of freedom, got %v", result)
+	}
+}
+
+func TestArrayArgMinArgMax(t *testing.T) {
+	arr, _ := NewArray([]float64{3, 1, 2, 9, 7, 8}, 2, 3)
+
+	// Test case 1: Index along the last axis
+	result, err := arr.ArgMin(1, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result.Data(), []float64{1, 1}, 0.0001) {
+		t.Errorf("Expected [1 1], got %v", result)
+	}
+
+	// Test case 2: Flat index over all axes
+	result, err = arr.ArgMax(AllAxes, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result.Data(), []float64{3}, 0.0001) {
+		t.Errorf("Expected 3, got %v", result)
+	}
+
+	// Test case 3: Empty axis
+	empty, _ := NewZeroArray(2, 0)
+	_, err = empty.ArgMin(1, false)
+	if err == nil {
+		t.Error("Expected an error for an empty axis, got none")
+	}
+
+	// Test case 4: A NaN anywhere in a lane is the result, as in NumPy
+	withNaN, _ := NewArray([]float64{1, math.NaN(), 3, 2, 5}, 5)
+	argMin, _ := withNaN.ArgMin(AllAxes, false)
+	argMax, _ := withNaN.ArgMax(AllAxes, false)
+	if !compareSlices(argMin.Data(), []float64{1}, 0) || !compareSlices(argMax.Data(), []float64{1}, 0) {
+		t.Errorf("Expected the index of the NaN, got %v and %v", argMin, argMax)
+	}
+	for _, reduce := range []func(int, bool, ...Option) (*Array, error){withNaN.MinWith, withNaN.MaxWith, withNaN.RangeWith} {
+		result, err := reduce(AllAxes, false)
+		if err != nil || !math.IsNaN(result.Data()[0]) {
+			t.Errorf("Expected NaN, got %v (err %v)", result, err)
+		}
+	}
+}
+
+func TestArrayMedianPercentile(t *testing.T) {
+	data := []float64{3, 1, 2, 40, 10, 20, 30}
+	arr, _ := NewArray(data[:6], 2, 3)
+
+	// Test case 1: Median along each row
+	result, err := arr.Median(2, 1, false)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result.Data(), []float64{2, 20}, 0.0001) {
+		t.Errorf("Expected [2 20], got %v", result)
+	}
+
+	// Test case 2: The input is not reordered
+	if !compareSlices(data, []float64{3, 1, 2, 40, 10, 20, 30}, 0) {
+		t.Errorf("Expected input to be unchanged, got %v", data)
+	}
+
+	// Test case 3: Percentile with interpolation over all axes
+	result, err = arr.Percentile(2, 25, AllAxes, true)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareInts(result.Shape(), []int{1, 1}) || !compareSlices(result.Data(), []float64{2.25}, 0.0001) {
+		t.Errorf("Expected [[2.25]], got %v", result)
+	}
+
+	// Test case 4: Percentile out of range
+	_, err = arr.Percentile(2, 101, 0, false)
+	if err == nil {
+		t.Error("Expected an error for percentile out of range, got none")
+	}
+	// Test case 5: Option forms choose the quantile method and skip NaN values
+	result, _ = arr.PercentileWith(25, AllAxes, false, WithQuantileMethod(QuantileLower))
+	if !compareSlices(result.Data(), []float64{2}, 0) {
+		t.Errorf("Expected [2], got %v", result)
+	}
+	withNaN, _ := NewArray([]float64{1, math.NaN(), 3, math.NaN(), math.NaN(), math.NaN()}, 2, 3)
+	result, _ = withNaN.MedianWith(1, false, WithNaNPolicy(NaNOmit))
+	sum, _ := withNaN.SumWith(1, false, WithNaNPolicy(NaNOmit), WithOut(make([]float64, 2)))
+	if !compareSlices(result.Data(), []float64{2, math.NaN()}, 0) || !compareSlices(sum.Data(), []float64{4, 0}, 0) {
+		t.Errorf("Expected medians [2 NaN] and sums [4 0], got %v and %v", result, sum)
+	}
+}
@@ -0,0 +1,179 @@
+package litearray
+
+import (
+	"math"
+	"strconv"
+	"sync/atomic"
+)
+
+// RoundingMode selects how results are rounded to the requested precision.
+type RoundingMode int
+
+const (
+	// RoundHalfAwayFromZero rounds to the nearest value and ties away from zero, like math.Round. It is the default.
+	RoundHalfAwayFromZero RoundingMode = iota
+	// RoundHalfEven rounds to the nearest value and ties to the even neighbour (banker's rounding).
+	RoundHalfEven
+	// RoundHalfDown rounds to the nearest value and ties toward zero.
+	RoundHalfDown
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundTruncate rounds toward zero.
+	RoundTruncate
+	// RoundSignificant treats the precision as a number of significant figures instead of
+	// decimal places and ties away from zero.
+	RoundSignificant
+)
+
+// String returns the name of the rounding mode.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfAwayFromZero:
+		return "half-away-from-zero"
+	case RoundHalfEven:
+		return "half-even"
+	case RoundHalfDown:
+		return "half-down"
+	case RoundCeiling:
+		return "ceiling"
+	case RoundFloor:
+		return "floor"
+	case RoundTruncate:
+		return "truncate"
+	case RoundSignificant:
+		return "significant"
+	}
+	return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// roundingMode is the mode used by every function that rounds its results.
+var roundingMode atomic.Int64
+
+// SetRoundingMode changes the rounding mode used by every function that takes a precision.
+// The default is RoundHalfAwayFromZero. WithRounding overrides the mode for a single call. An
+// unknown mode is rejected with ErrInvalidArgument and leaves the current mode in place.
+func SetRoundingMode(mode RoundingMode) error {
+	if mode < RoundHalfAwayFromZero || mode > RoundSignificant {
+		return argumentError("SetRoundingMode", "unknown rounding mode %d", int(mode))
+	}
+	roundingMode.Store(int64(mode))
+	return nil
+}
+
+// Round rounds value to precision decimal places using the given mode, or to precision
+// significant figures for RoundSignificant. Rounding works on the shortest decimal
+// representation of value, so 2.675 rounds to 2.68 even though the nearest float64 is
+// slightly below it, and values too large to have digits at that precision are returned
+// unchanged instead of overflowing. A negative precision, or a precision below one for
+// RoundSignificant, leaves the value unchanged, as do NaN and infinities.
+func Round(value float64, precision int, mode RoundingMode) float64 {
+	if precision < 0 || value == 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	if mode == RoundSignificant && precision < 1 {
+		return value
+	}
+
+	// Split the shortest representation into its digits and the position of the decimal point,
+	// so that value = 0.digits × 10^point
+	mantissa, exp := splitExponent(strconv.FormatFloat(math.Abs(value), 'e', -1, 64))
+	digits := make([]byte, 0, len(mantissa))
+	for i := 0; i < len(mantissa); i++ {
+		if mantissa[i] != '.' {
+			digits = append(digits, mantissa[i])
+		}
+	}
+	point := exp + 1
+
+	// Work out how many leading digits survive
+	keep := point + precision
+	if mode == RoundSignificant {
+		keep = precision
+	}
+	if keep >= len(digits) {
+		return value
+	}
+
+	// Classify the discarded digits against half a unit in the last kept place
+	above, tie := false, false
+	if keep >= 0 {
+		rest := digits[keep:]
+		switch {
+		case rest[0] > '5':
+			above = true
+		case rest[0] == '5':
+			tie = true
+			for _, d := range rest[1:] {
+				if d != '0' {
+					tie, above = false, true
+					break
+				}
+			}
+		}
+	}
+	negative := value < 0
+
+	// Decide whether the magnitude of the kept digits grows by one unit
+	increment := false
+	switch mode {
+	case RoundHalfAwayFromZero, RoundSignificant:
+		increment = above || tie
+	case RoundHalfEven:
+		lastOdd := keep > 0 && (digits[keep-1]-'0')%2 == 1
+		increment = above || tie && lastOdd
+	case RoundHalfDown:
+		increment = above
+	case RoundCeiling:
+		increment = !negative
+	case RoundFloor:
+		increment = negative
+	case RoundTruncate:
+		increment = false
+	}
+
+	// Keep the leading digits; when none survive the kept value is zero
+	scale := point - keep
+	kept := []byte{'0'}
+	if keep > 0 {
+		kept = append([]byte(nil), digits[:keep]...)
+	}
+	if increment {
+		kept = incrementDigits(kept)
+	}
+
+	// Rebuild the value as kept × 10^scale and let ParseFloat pick the nearest float64
+	buf := make([]byte, 0, len(kept)+8)
+	if negative {
+		buf = append(buf, '-')
+	}
+	buf = append(buf, kept...)
+	buf = append(buf, 'e')
+	buf = strconv.AppendInt(buf, int64(scale), 10)
+	result, _ := strconv.ParseFloat(string(buf), 64)
+	return result
+}
+
+// splitExponent splits the output of FormatFloat in 'e' format into its mantissa and exponent.
+func splitExponent(text string) (string, int) {
+	for i := len(text) - 1; i >= 0; i-- {
+		if text[i] == 'e' {
+			exp, _ := strconv.Atoi(text[i+1:])
+			return text[:i], exp
+		}
+	}
+	return text, 0
+}
+
+// incrementDigits adds one to a string of decimal digits, growing it when the carry overflows.
+func incrementDigits(digits []byte) []byte {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return digits
+		}
+		digits[i] = '0'
+	}
+	return append([]byte{'1'}, digits...)
+}
@@ -0,0 +1,80 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		value     float64
+		precision int
+		mode      RoundingMode
+		expected  float64
+	}{
+		// Ties follow the selected mode
+		{2.5, 0, RoundHalfAwayFromZero, 3},
+		{-2.5, 0, RoundHalfAwayFromZero, -3},
+		{2.5, 0, RoundHalfEven, 2},
+		{3.5, 0, RoundHalfEven, 4},
+		{0.125, 2, RoundHalfEven, 0.12},
+		{2.5, 0, RoundHalfDown, 2},
+		{-2.5, 0, RoundHalfDown, -2},
+		{2.51, 0, RoundHalfDown, 3},
+		// Directed modes
+		{1.21, 1, RoundCeiling, 1.3},
+		{-1.29, 1, RoundCeiling, -1.2},
+		{1.29, 1, RoundFloor, 1.2},
+		{-1.21, 1, RoundFloor, -1.3},
+		{-1.29, 1, RoundTruncate, -1.2},
+		{0.0004, 2, RoundCeiling, 0.01},
+		{0.0004, 2, RoundHalfAwayFromZero, 0},
+		// Significant figures
+		{123456, 2, RoundSignificant, 120000},
+		{0.0012345, 3, RoundSignificant, 0.00123},
+		{9.996, 3, RoundSignificant, 10},
+		// Decimal representation is honoured where x*10^p would not be
+		{2.675, 2, RoundHalfAwayFromZero, 2.68},
+		{1.005, 2, RoundHalfAwayFromZero, 1.01},
+		// Values too large to have digits at the precision are unchanged
+		{1e300, 10, RoundHalfAwayFromZero, 1e300},
+		{math.MaxFloat64, 10, RoundFloor, math.MaxFloat64},
+		// No rounding
+		{1.23456, -1, RoundHalfEven, 1.23456},
+	}
+
+	for _, test := range tests {
+		result := Round(test.value, test.precision, test.mode)
+		if result != test.expected {
+			t.Errorf("Round(%v, %d, %v): expected %v, got %v", test.value, test.precision, test.mode, test.expected, result)
+		}
+	}
+
+	// NaN passes through untouched
+	if !math.IsNaN(Round(math.NaN(), 2, RoundHalfEven)) {
+		t.Error("Expected NaN to pass through")
+	}
+}
+
+func TestSetRoundingMode(t *testing.T) {
+	SetRoundingMode(RoundHalfEven)
+	defer SetRoundingMode(RoundHalfAwayFromZero)
+
+	// Test case 1: The package mode applies to every function
+	result, err := AddArrays(0, []float64{0.5, 1.5, 2.5}, []float64{0, 0, 0})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !compareSlices(result, []float64{0, 2, 2}, 0) {
+		t.Errorf("Expected [0 2 2], got %v", result)
+	}
+	// Test case 2: An unknown mode is rejected and does not break later calls
+	if err := SetRoundingMode(RoundSignificant + 1); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	result, err = AddArrays(0, []float64{2.5}, []float64{0})
+	if err != nil || !compareSlices(result, []float64{2}, 0) {
+		t.Errorf("Expected [2] under the previous mode, got %v (err %v)", result, err)
+	}
+}
@@ -0,0 +1,617 @@
+package litearray
+
+import "sort"
+
+// Sparse is a sparse matrix stored in one of the COO, CSR or CSC formats. Only the non-zero
+// entries are stored, so memory grows with their number rather than with rows × columns.
+// Functions that take a Sparse accept any of the formats and convert when they need to.
+type Sparse interface {
+	// Dims returns the number of rows and columns.
+	Dims() (rows, cols int)
+	// NNZ returns the number of stored entries.
+	NNZ() int
+	// At returns the entry at row i and column j.
+	At(i, j int) (float64, error)
+	// MatVec calculates the matrix-vector product A·x.
+	MatVec(x []float64, opts ...Option) ([]float64, error)
+	// ToCOO, ToCSR and ToCSC convert to the other formats. Converting to the same format returns
+	// the matrix itself.
+	ToCOO() *COO
+	ToCSR() *CSR
+	ToCSC() *CSC
+	// ToMatrix expands the matrix into a dense 2D slice.
+	ToMatrix() [][]float64
+}
+
+// COO is a sparse matrix in coordinate format: a list of (row, column, value) triplets in any
+// order. It is the easiest format to build incrementally; duplicate entries are summed when it is
+// converted to CSR or CSC.
+type COO struct {
+	rows, cols int
+	rowIndex   []int
+	colIndex   []int
+	values     []float64
+}
+
+// compressed holds the arrays shared by the CSR and CSC formats. Line i of the major axis holds
+// the entries indptr[i]:indptr[i+1] of indices and values, with indices along the minor axis
+// strictly increasing within each line.
+type compressed struct {
+	major, minor int
+	indptr       []int
+	indices      []int
+	values       []float64
+}
+
+// CSR is a sparse matrix in compressed sparse row format. It is the format of choice for
+// matrix-vector products, row slicing and the iterative solvers.
+type CSR struct {
+	compressed
+}
+
+// CSC is a sparse matrix in compressed sparse column format, the transpose layout of CSR. It is
+// the natural format for column access and for products with the transpose.
+type CSC struct {
+	compressed
+}
+
+// NewCOO creates a rows×cols sparse matrix from triplets: entry k is values[k] at row rowIndex[k]
+// and column colIndex[k]. The slices are copied.
+func NewCOO(rows, cols int, rowIndex, colIndex []int, values []float64) (*COO, error) {
+	const op = "NewCOO"
+	if err := checkDimensions(op, rows, cols); err != nil {
+		return nil, err
+	}
+	if len(rowIndex) != len(values) || len(colIndex) != len(values) {
+		return nil, shapeError(op, [][]int{{len(rowIndex)}, {len(colIndex)}, {len(values)}}, "row indices, column indices and values must have the same length")
+	}
+	m := &COO{rows: rows, cols: cols}
+	for k, v := range values {
+		if err := m.Append(rowIndex[k], colIndex[k], v); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// NewCOOFromMatrix creates a COO matrix holding the non-zero entries of a dense matrix.
+func NewCOOFromMatrix(matrix [][]float64) (*COO, error) {
+	csr, err := NewCSRFromMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+	return csr.ToCOO(), nil
+}
+
+// Append adds value at row i and column j. An entry already stored there is not replaced; the two
+// are summed when the matrix is converted.
+func (m *COO) Append(i, j int, value float64) error {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		return argumentError("COO.Append", "index (%d, %d) is out of bounds for a %dx%d matrix", i, j, m.rows, m.cols)
+	}
+	m.rowIndex = append(m.rowIndex, i)
+	m.colIndex = append(m.colIndex, j)
+	m.values = append(m.values, value)
+	return nil
+}
+
+// Dims returns the number of rows and columns.
+func (m *COO) Dims() (rows, cols int) { return m.rows, m.cols }
+
+// NNZ returns the number of stored triplets, counting duplicates separately.
+func (m *COO) NNZ() int { return len(m.values) }
+
+// At returns the entry at row i and column j, summing duplicates. It takes O(NNZ) time.
+func (m *COO) At(i, j int) (float64, error) {
+	if err := checkIndex("COO.At", i, j, m.rows, m.cols); err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	for k, v := range m.values {
+		if m.rowIndex[k] == i && m.colIndex[k] == j {
+			sum += v
+		}
+	}
+	return sum, nil
+}
+
+// MatVec calculates the matrix-vector product A·x.
+func (m *COO) MatVec(x []float64, opts ...Option) ([]float64, error) {
+	return sparseMatVec("COO.MatVec", m, x, opts, func(y []float64) {
+		for k, v := range m.values {
+			y[m.rowIndex[k]] += v * x[m.colIndex[k]]
+		}
+	})
+}
+
+// Transpose returns the transpose of the matrix.
+func (m *COO) Transpose() *COO {
+	return &COO{
+		rows:     m.cols,
+		cols:     m.rows,
+		rowIndex: append([]int(nil), m.colIndex...),
+		colIndex: append([]int(nil), m.rowIndex...),
+		values:   append([]float64(nil), m.values...),
+	}
+}
+
+// ToCOO returns the matrix itself.
+func (m *COO) ToCOO() *COO { return m }
+
+// ToCSR converts the matrix to CSR format, summing duplicate entries.
+func (m *COO) ToCSR() *CSR {
+	return &CSR{compressTriplets(m.rows, m.cols, m.rowIndex, m.colIndex, m.values)}
+}
+
+// ToCSC converts the matrix to CSC format, summing duplicate entries.
+func (m *COO) ToCSC() *CSC {
+	return &CSC{compressTriplets(m.cols, m.rows, m.colIndex, m.rowIndex, m.values)}
+}
+
+// ToMatrix expands the matrix into a dense 2D slice.
+func (m *COO) ToMatrix() [][]float64 {
+	matrix := newMatrix(m.rows, m.cols)
+	for k, v := range m.values {
+		matrix[m.rowIndex[k]][m.colIndex[k]] += v
+	}
+	return matrix
+}
+
+// NewCSR creates a rows×cols CSR matrix from its arrays: row i holds the entries
+// indptr[i]:indptr[i+1] of indices and values, with column indices strictly increasing within each
+// row. The slices are used without copying.
+func NewCSR(rows, cols int, indptr, indices []int, values []float64) (*CSR, error) {
+	c, err := newCompressed("NewCSR", rows, cols, indptr, indices, values)
+	if err != nil {
+		return nil, err
+	}
+	return &CSR{c}, nil
+}
+
+// NewCSRFromMatrix creates a CSR matrix holding the non-zero entries of a dense matrix.
+func NewCSRFromMatrix(matrix [][]float64) (*CSR, error) {
+	const op = "NewCSRFromMatrix"
+	cols, err := checkRectangular(op, matrix)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDimensions(op, len(matrix), cols); err != nil {
+		return nil, err
+	}
+
+	m := &CSR{compressed{major: len(matrix), minor: cols, indptr: make([]int, len(matrix)+1)}}
+	for i, row := range matrix {
+		for j, v := range row {
+			if v != 0 {
+				m.indices = append(m.indices, j)
+				m.values = append(m.values, v)
+			}
+		}
+		m.indptr[i+1] = len(m.values)
+	}
+	return m, nil
+}
+
+// Dims returns the number of rows and columns.
+func (m *CSR) Dims() (rows, cols int) { return m.major, m.minor }
+
+// At returns the entry at row i and column j, searching the row in O(log NNZ) time.
+func (m *CSR) At(i, j int) (float64, error) {
+	if err := checkIndex("CSR.At", i, j, m.major, m.minor); err != nil {
+		return 0, err
+	}
+	return m.at(i, j), nil
+}
+
+// MatVec calculates the matrix-vector product A·x. Rows are split between goroutines with
+// WithParallelism.
+func (m *CSR) MatVec(x []float64, opts ...Option) ([]float64, error) {
+	const op = "CSR.MatVec"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	return sparseMatVec(op, m, x, opts, func(y []float64) {
+		c.parallelChunks(m.major, max(1, minChunk*m.major/max(len(m.values), 1)), func(lo, hi int) {
+			m.mulVec(y, x, lo, hi)
+		})
+	})
+}
+
+// Transpose returns the transpose of the matrix in CSR format.
+func (m *CSR) Transpose() *CSR { return &CSR{m.transpose()} }
+
+// ToCOO converts the matrix to COO format.
+func (m *CSR) ToCOO() *COO {
+	rowIndex, colIndex := m.expand()
+	return &COO{rows: m.major, cols: m.minor, rowIndex: rowIndex, colIndex: colIndex, values: append([]float64(nil), m.values...)}
+}
+
+// ToCSR returns the matrix itself.
+func (m *CSR) ToCSR() *CSR { return m }
+
+// ToCSC converts the matrix to CSC format.
+func (m *CSR) ToCSC() *CSC { return &CSC{m.transpose()} }
+
+// ToMatrix expands the matrix into a dense 2D slice.
+func (m *CSR) ToMatrix() [][]float64 {
+	matrix := newMatrix(m.major, m.minor)
+	for i := range matrix {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			matrix[i][m.indices[k]] = m.values[k]
+		}
+	}
+	return matrix
+}
+
+// Add calculates the element-wise sum A + B of two sparse matrices of the same shape.
+func (m *CSR) Add(b Sparse, opts ...Option) (*CSR, error) {
+	return m.elementwise("CSR.Add", b, false, func(x, y float64) float64 { return x + y }, opts)
+}
+
+// Subtract calculates the element-wise difference A − B of two sparse matrices of the same shape.
+func (m *CSR) Subtract(b Sparse, opts ...Option) (*CSR, error) {
+	return m.elementwise("CSR.Subtract", b, false, func(x, y float64) float64 { return x - y }, opts)
+}
+
+// Multiply calculates the element-wise product of two sparse matrices of the same shape. Only
+// entries stored in both matrices can be non-zero.
+func (m *CSR) Multiply(b Sparse, opts ...Option) (*CSR, error) {
+	return m.elementwise("CSR.Multiply", b, true, func(x, y float64) float64 { return x * y }, opts)
+}
+
+// Scale multiplies every entry by factor.
+func (m *CSR) Scale(factor float64, opts ...Option) (*CSR, error) {
+	const op = "CSR.Scale"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, len(m.values))
+	for k, v := range m.values {
+		values[k] = factor * v
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{m.major, m.minor}}, values)
+
+	return &CSR{compressed{m.major, m.minor, m.indptr, m.indices, values}}, nil
+}
+
+// MatMul calculates the matrix product A·B of two sparse matrices with Gustavson's row-by-row
+// algorithm, in time proportional to the number of multiplications of non-zero entries.
+func (m *CSR) MatMul(b Sparse, opts ...Option) (*CSR, error) {
+	const op = "CSR.MatMul"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	rows, inner := b.Dims()
+	if m.minor != rows {
+		return nil, shapeError(op, [][]int{{m.major, m.minor}, {rows, inner}}, "matrices of shapes %dx%d and %dx%d cannot be multiplied", m.major, m.minor, rows, inner)
+	}
+	other := b.ToCSR()
+
+	// Accumulate each row of the product in a dense row, remembering which columns were touched
+	result := &CSR{compressed{major: m.major, minor: other.minor, indptr: make([]int, m.major+1)}}
+	accumulator := make([]float64, other.minor)
+	touched := make([]bool, other.minor)
+	var columns []int
+	for i := 0; i < m.major; i++ {
+		columns = columns[:0]
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			p, v := m.indices[k], m.values[k]
+			for l := other.indptr[p]; l < other.indptr[p+1]; l++ {
+				j := other.indices[l]
+				if !touched[j] {
+					touched[j] = true
+					columns = append(columns, j)
+				}
+				accumulator[j] += v * other.values[l]
+			}
+		}
+		sort.Ints(columns)
+		for _, j := range columns {
+			if accumulator[j] != 0 {
+				result.indices = append(result.indices, j)
+				result.values = append(result.values, accumulator[j])
+			}
+			accumulator[j], touched[j] = 0, false
+		}
+		result.indptr[i+1] = len(result.values)
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{m.major, m.minor}, {rows, inner}}, result.values)
+
+	return result, nil
+}
+
+// NewCSC creates a rows×cols CSC matrix from its arrays: column j holds the entries
+// indptr[j]:indptr[j+1] of indices and values, with row indices strictly increasing within each
+// column. The slices are used without copying.
+func NewCSC(rows, cols int, indptr, indices []int, values []float64) (*CSC, error) {
+	c, err := newCompressed("NewCSC", cols, rows, indptr, indices, values)
+	if err != nil {
+		return nil, err
+	}
+	return &CSC{c}, nil
+}
+
+// NewCSCFromMatrix creates a CSC matrix holding the non-zero entries of a dense matrix.
+func NewCSCFromMatrix(matrix [][]float64) (*CSC, error) {
+	csr, err := NewCSRFromMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+	return csr.ToCSC(), nil
+}
+
+// Dims returns the number of rows and columns.
+func (m *CSC) Dims() (rows, cols int) { return m.minor, m.major }
+
+// At returns the entry at row i and column j, searching the column in O(log NNZ) time.
+func (m *CSC) At(i, j int) (float64, error) {
+	if err := checkIndex("CSC.At", i, j, m.minor, m.major); err != nil {
+		return 0, err
+	}
+	return m.at(j, i), nil
+}
+
+// MatVec calculates the matrix-vector product A·x by scattering each column.
+func (m *CSC) MatVec(x []float64, opts ...Option) ([]float64, error) {
+	return sparseMatVec("CSC.MatVec", m, x, opts, func(y []float64) {
+		for j := 0; j < m.major; j++ {
+			for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+				y[m.indices[k]] += m.values[k] * x[j]
+			}
+		}
+	})
+}
+
+// Transpose returns the transpose of the matrix in CSC format.
+func (m *CSC) Transpose() *CSC { return &CSC{m.transpose()} }
+
+// ToCOO converts the matrix to COO format.
+func (m *CSC) ToCOO() *COO {
+	colIndex, rowIndex := m.expand()
+	return &COO{rows: m.minor, cols: m.major, rowIndex: rowIndex, colIndex: colIndex, values: append([]float64(nil), m.values...)}
+}
+
+// ToCSR converts the matrix to CSR format.
+func (m *CSC) ToCSR() *CSR { return &CSR{m.transpose()} }
+
+// ToCSC returns the matrix itself.
+func (m *CSC) ToCSC() *CSC { return m }
+
+// ToMatrix expands the matrix into a dense 2D slice.
+func (m *CSC) ToMatrix() [][]float64 {
+	matrix := newMatrix(m.minor, m.major)
+	for j := 0; j < m.major; j++ {
+		for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+			matrix[m.indices[k]][j] = m.values[k]
+		}
+	}
+	return matrix
+}
+
+// NNZ returns the number of stored entries.
+func (m *compressed) NNZ() int { return len(m.values) }
+
+// at returns the entry on line i of the major axis at position j of the minor axis.
+func (m *compressed) at(i, j int) float64 {
+	line := m.indices[m.indptr[i]:m.indptr[i+1]]
+	k := sort.SearchInts(line, j)
+	if k < len(line) && line[k] == j {
+		return m.values[m.indptr[i]+k]
+	}
+	return 0
+}
+
+// mulVec writes the products of lines lo to hi with x into y.
+func (m *compressed) mulVec(y, x []float64, lo, hi int) {
+	for i := lo; i < hi; i++ {
+		sum := 0.0
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			sum += m.values[k] * x[m.indices[k]]
+		}
+		y[i] = sum
+	}
+}
+
+// transpose swaps the major and minor axes with a counting sort, which keeps the indices of
+// every new line in increasing order.
+func (m *compressed) transpose() compressed {
+	t := compressed{
+		major:   m.minor,
+		minor:   m.major,
+		indptr:  make([]int, m.minor+1),
+		indices: make([]int, len(m.indices)),
+		values:  make([]float64, len(m.values)),
+	}
+	for _, j := range m.indices {
+		t.indptr[j+1]++
+	}
+	for j := 0; j < t.major; j++ {
+		t.indptr[j+1] += t.indptr[j]
+	}
+	next := append([]int(nil), t.indptr[:t.major]...)
+	for i := 0; i < m.major; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			j := m.indices[k]
+			t.indices[next[j]] = i
+			t.values[next[j]] = m.values[k]
+			next[j]++
+		}
+	}
+	return t
+}
+
+// expand returns the major and minor index of every stored entry.
+func (m *compressed) expand() (major, minor []int) {
+	major = make([]int, len(m.values))
+	for i := 0; i < m.major; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			major[k] = i
+		}
+	}
+	return major, append([]int(nil), m.indices...)
+}
+
+// elementwise merges the rows of two matrices of the same shape, applying fn to each pair of
+// entries, with zero standing in for a missing entry. With intersect only entries stored in both
+// are kept. Zero results are dropped.
+func (m *CSR) elementwise(op string, b Sparse, intersect bool, fn func(x, y float64) float64, opts []Option) (*CSR, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := b.Dims()
+	if rows != m.major || cols != m.minor {
+		return nil, shapeError(op, [][]int{{m.major, m.minor}, {rows, cols}}, "matrices of shapes %dx%d and %dx%d must have the same shape", m.major, m.minor, rows, cols)
+	}
+	other := b.ToCSR()
+
+	result := &CSR{compressed{major: m.major, minor: m.minor, indptr: make([]int, m.major+1)}}
+	emit := func(j int, v float64) {
+		if v != 0 {
+			result.indices = append(result.indices, j)
+			result.values = append(result.values, v)
+		}
+	}
+	for i := 0; i < m.major; i++ {
+		k, l := m.indptr[i], other.indptr[i]
+		for k < m.indptr[i+1] || l < other.indptr[i+1] {
+			switch {
+			case l == other.indptr[i+1] || (k < m.indptr[i+1] && m.indices[k] < other.indices[l]):
+				if !intersect {
+					emit(m.indices[k], fn(m.values[k], 0))
+				}
+				k++
+			case k == m.indptr[i+1] || other.indices[l] < m.indices[k]:
+				if !intersect {
+					emit(other.indices[l], fn(0, other.values[l]))
+				}
+				l++
+			default:
+				emit(m.indices[k], fn(m.values[k], other.values[l]))
+				k++
+				l++
+			}
+		}
+		result.indptr[i+1] = len(result.values)
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{m.major, m.minor}, {rows, cols}}, result.values)
+
+	return result, nil
+}
+
+// newCompressed validates the arrays of a CSR or CSC matrix.
+func newCompressed(op string, major, minor int, indptr, indices []int, values []float64) (compressed, error) {
+	m := compressed{major: major, minor: minor, indptr: indptr, indices: indices, values: values}
+	if err := checkDimensions(op, major, minor); err != nil {
+		return m, err
+	}
+	if len(indptr) != major+1 {
+		return m, shapeError(op, [][]int{{len(indptr)}}, "index pointer has length %d, expected %d", len(indptr), major+1)
+	}
+	if len(indices) != len(values) {
+		return m, shapeError(op, [][]int{{len(indices)}, {len(values)}}, "indices and values have lengths %d and %d", len(indices), len(values))
+	}
+	if indptr[0] != 0 || indptr[major] != len(values) {
+		return m, argumentError(op, "index pointer must run from 0 to %d", len(values))
+	}
+	for i := 0; i < major; i++ {
+		if indptr[i+1] < indptr[i] || indptr[i+1] > len(values) {
+			return m, argumentError(op, "index pointer must be non-decreasing and at most %d, got %d after %d", len(values), indptr[i+1], indptr[i])
+		}
+	}
+
+	// Only now that the index pointer is sound can it address the indices
+	for i := 0; i < major; i++ {
+		for k := indptr[i]; k < indptr[i+1]; k++ {
+			if indices[k] < 0 || indices[k] >= minor {
+				return m, argumentError(op, "index %d is out of bounds for size %d", indices[k], minor)
+			}
+			if k > indptr[i] && indices[k] <= indices[k-1] {
+				return m, argumentError(op, "indices must be strictly increasing within each line, got %d after %d", indices[k], indices[k-1])
+			}
+		}
+	}
+	return m, nil
+}
+
+// compressTriplets builds compressed arrays from triplets, sorting each line and summing
+// duplicates.
+func compressTriplets(major, minor int, majorIndex, minorIndex []int, values []float64) compressed {
+	// Count the entries of each line and bucket them
+	indptr := make([]int, major+1)
+	for _, i := range majorIndex {
+		indptr[i+1]++
+	}
+	for i := 0; i < major; i++ {
+		indptr[i+1] += indptr[i]
+	}
+	order := make([]int, len(values))
+	next := append([]int(nil), indptr[:major]...)
+	for k, i := range majorIndex {
+		order[next[i]] = k
+		next[i]++
+	}
+
+	// Sort each line by minor index and merge duplicates
+	m := compressed{major: major, minor: minor, indptr: make([]int, major+1)}
+	for i := 0; i < major; i++ {
+		line := order[indptr[i]:indptr[i+1]]
+		sort.Slice(line, func(a, b int) bool { return minorIndex[line[a]] < minorIndex[line[b]] })
+		for n, k := range line {
+			if n > 0 && minorIndex[k] == minorIndex[line[n-1]] {
+				m.values[len(m.values)-1] += values[k]
+				continue
+			}
+			m.indices = append(m.indices, minorIndex[k])
+			m.values = append(m.values, values[k])
+		}
+		m.indptr[i+1] = len(m.values)
+	}
+	return m
+}
+
+// sparseMatVec validates x and calls product to accumulate A·x into a zeroed result.
+func sparseMatVec(op string, m Sparse, x []float64, opts []Option, product func(y []float64)) ([]float64, error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := m.Dims()
+	if len(x) != cols {
+		return nil, shapeError(op, [][]int{{rows, cols}, {len(x)}}, "vector has length %d, expected %d", len(x), cols)
+	}
+	if err := c.checkNaN(op, x); err != nil {
+		return nil, err
+	}
+
+	// Compute into a fresh slice in case WithOut overlaps x
+	y := make([]float64, rows)
+	product(y)
+	result, err := c.result(op, rows)
+	if err != nil {
+		return nil, err
+	}
+	copy(result, y)
+
+	// Apply rounding if precision is non-negative
+	c.round(op, [][]int{{rows, cols}, {len(x)}}, result)
+
+	return result, nil
+}
+
+// checkIndex checks that (i, j) lies inside a rows×cols matrix.
+func checkIndex(op string, i, j, rows, cols int) error {
+	if i < 0 || i >= rows || j < 0 || j >= cols {
+		return argumentError(op, "index (%d, %d) is out of bounds for a %dx%d matrix", i, j, rows, cols)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package litearray
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSparseConversions(t *testing.T) {
+	dense := [][]float64{{1, 0, 2}, {0, 0, 3}, {4, 5, 0}}
+
+	// Test case 1: Round trips through every format
+	csr, err := NewCSRFromMatrix(dense)
+	if err != nil || csr.NNZ() != 5 {
+		t.Fatalf("Expected 5 stored entries, got %v (err %v)", csr, err)
+	}
+	for _, m := range []Sparse{csr, csr.ToCSC(), csr.ToCOO(), csr.ToCSC().ToCSR(), csr.ToCOO().ToCSC()} {
+		if !compareMatrices(m.ToMatrix(), dense, 0) {
+			t.Errorf("Expected %v, got %v", dense, m.ToMatrix())
+		}
+	}
+
+	// Test case 2: Duplicate COO entries are summed
+	coo, err := NewCOO(2, 2, []int{1, 0, 1}, []int{0, 1, 0}, []float64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v, _ := coo.At(1, 0); v != 4 || coo.ToCSR().NNZ() != 2 || !compareMatrices(coo.ToCSC().ToMatrix(), [][]float64{{0, 2}, {4, 0}}, 0) {
+		t.Errorf("Expected duplicates summed to 4, got %v", coo.ToCSR().ToMatrix())
+	}
+
+	// Test case 3: Element access and transpose
+	if v, _ := csr.At(2, 1); v != 5 {
+		t.Errorf("Expected 5, got %v", v)
+	}
+	if v, _ := csr.ToCSC().At(0, 1); v != 0 {
+		t.Errorf("Expected 0, got %v", v)
+	}
+	if !compareMatrices(csr.Transpose().ToMatrix(), [][]float64{{1, 0, 4}, {0, 0, 5}, {2, 3, 0}}, 0) {
+		t.Errorf("Unexpected transpose %v", csr.Transpose().ToMatrix())
+	}
+
+	// Test case 4: Invalid input
+	if _, err := NewCSR(2, 2, []int{0, 2, 2}, []int{1, 0}, []float64{1, 2}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument for unsorted indices, got %v", err)
+	}
+	if _, err := NewCSR(2, 2, []int{0, 5, 2}, []int{0, 1}, []float64{1, 2}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument for a malformed index pointer, got %v", err)
+	}
+	if _, err := NewCSC(2, 2, []int{0, 1}, []int{0}, []float64{1}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if err := coo.Append(2, 0, 1); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestSparseArithmetic(t *testing.T) {
+	a, _ := NewCSRFromMatrix([][]float64{{1, 0, 2}, {0, 3, 0}})
+	b, _ := NewCSRFromMatrix([][]float64{{1, 1, 0}, {0, -3, 4}})
+
+	// Test case 1: Matrix-vector products agree across formats
+	x := []float64{1, 2, 3}
+	for _, m := range []Sparse{a, a.ToCSC(), a.ToCOO()} {
+		y, err := m.MatVec(x)
+		if err != nil || !compareSlices(y, []float64{7, 6}, 0) {
+			t.Errorf("Expected [7 6], got %v (err %v)", y, err)
+		}
+	}
+	if _, err := a.MatVec([]float64{1, 2}); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+
+	// Test case 2: Element-wise operations drop cancelled entries
+	sum, _ := a.Add(b)
+	difference, _ := a.Subtract(b.ToCOO())
+	product, _ := a.Multiply(b)
+	if !compareMatrices(sum.ToMatrix(), [][]float64{{2, 1, 2}, {0, 0, 4}}, 0) || sum.NNZ() != 4 {
+		t.Errorf("Unexpected sum %v with %d entries", sum.ToMatrix(), sum.NNZ())
+	}
+	if !compareMatrices(difference.ToMatrix(), [][]float64{{0, -1, 2}, {0, 6, -4}}, 0) || difference.NNZ() != 4 {
+		t.Errorf("Unexpected difference %v with %d entries", difference.ToMatrix(), difference.NNZ())
+	}
+	if !compareMatrices(product.ToMatrix(), [][]float64{{1, 0, 0}, {0, -9, 0}}, 0) || product.NNZ() != 2 {
+		t.Errorf("Unexpected product %v with %d entries", product.ToMatrix(), product.NNZ())
+	}
+
+	// Test case 3: Matrix product matches the dense one
+	c, err := a.MatMul(b.Transpose())
+	expected, _ := MatMul(a.ToMatrix(), b.Transpose().ToMatrix())
+	if err != nil || !compareMatrices(c.ToMatrix(), expected, 0) {
+		t.Errorf("Expected %v, got %v (err %v)", expected, c, err)
+	}
+	if _, err := a.MatMul(b); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+
+	// Test case 4: Scaling with rounding
+	scaled, _ := a.Scale(1.0/3, WithPrecision(2))
+	if !compareMatrices(scaled.ToMatrix(), [][]float64{{0.33, 0, 0.67}, {0, 1, 0}}, 0) {
+		t.Errorf("Unexpected scaled matrix %v", scaled.ToMatrix())
+	}
+}
@@ -0,0 +1,233 @@
+package litearray
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// epsilon is the distance from 1 to the next larger float64.
+const epsilon = 0x1p-52
+
+// SVD computes the full singular value decomposition A = U·diag(s)·Vᵀ of an m×n matrix, where U
+// is m×m, V is n×n, both orthogonal, and s holds the min(m, n) singular values in decreasing
+// order. The results are rounded according to opts.
+func SVD(matrix [][]float64, opts ...Option) (u [][]float64, s []float64, v [][]float64, err error) {
+	return svd("SVD", matrix, mat.SVDFull, opts)
+}
+
+// SVDThin computes the thin singular value decomposition A = U·diag(s)·Vᵀ of an m×n matrix,
+// keeping only the k = min(m, n) columns of U and V that meet a singular value, so U is m×k and
+// V is n×k.
+func SVDThin(matrix [][]float64, opts ...Option) (u [][]float64, s []float64, v [][]float64, err error) {
+	return svd("SVDThin", matrix, mat.SVDThin, opts)
+}
+
+func svd(op string, matrix [][]float64, kind mat.SVDKind, opts []Option) (u [][]float64, s []float64, v [][]float64, err error) {
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if _, err := checkColumns(op, matrix); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return nil, nil, nil, err
+	}
+
+	factors, err := factorSVD(op, matrix, kind)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var uDense, vDense mat.Dense
+	factors.UTo(&uDense)
+	factors.VTo(&vDense)
+	s = factors.Values(nil)
+
+	// Apply rounding if precision is non-negative
+	uData, vData := uDense.RawMatrix().Data, vDense.RawMatrix().Data
+	c.round(op, matrixShapes(matrix), uData)
+	c.round(op, matrixShapes(matrix), s)
+	c.round(op, matrixShapes(matrix), vData)
+
+	return rowsOfDense(&uDense), s, rowsOfDense(&vDense), nil
+}
+
+// PseudoInverse calculates the Moore–Penrose pseudo-inverse of an m×n matrix from its singular
+// value decomposition, giving an n×m matrix. Singular values at or below the tolerance are treated
+// as zero instead of being inverted, so near-singular matrices give a usable least-squares inverse
+// rather than garbage. The tolerance defaults to max(m, n)·ε·σ_max and can be set with
+// WithTolerance. With WithOut the rows of the result share the given buffer.
+func PseudoInverse(matrix [][]float64, opts ...Option) ([][]float64, error) {
+	const op = "PseudoInverse"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return nil, err
+	}
+	n, err := checkColumns(op, matrix)
+	if err != nil {
+		return nil, err
+	}
+	m := len(matrix)
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return nil, err
+	}
+
+	factors, err := factorSVD(op, matrix, mat.SVDThin)
+	if err != nil {
+		return nil, err
+	}
+	var u, v mat.Dense
+	factors.UTo(&u)
+	factors.VTo(&v)
+	s := factors.Values(nil)
+	rank := c.rank(s, m, n)
+
+	data, err := c.result(op, n*m)
+	if err != nil {
+		return nil, err
+	}
+	clear(data)
+	pinv := rowsOf(data, n, m)
+
+	// A⁺ = V·diag(1/s)·Uᵀ over the singular values that are kept
+	for k := 0; k < rank; k++ {
+		inv := 1 / s[k]
+		for i := 0; i < n; i++ {
+			vik := v.At(i, k) * inv
+			if vik == 0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				pinv[i][j] += vik * u.At(j, k)
+			}
+		}
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), data)
+
+	return pinv, nil
+}
+
+// MatrixRank calculates the numerical rank of an m×n matrix: the number of singular values above
+// the tolerance, which defaults to max(m, n)·ε·σ_max and can be set with WithTolerance.
+func MatrixRank(matrix [][]float64, opts ...Option) (int, error) {
+	const op = "MatrixRank"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return 0, err
+	}
+	n, err := checkColumns(op, matrix)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return 0, err
+	}
+
+	s, err := singularValues(op, matrix)
+	if err != nil {
+		return 0, err
+	}
+	return c.rank(s, len(matrix), n), nil
+}
+
+// ConditionNumber calculates the 2-norm condition number σ_max/σ_min of an m×n matrix, which
+// bounds how much relative errors in b can grow in the solution of A·x = b. Well-conditioned
+// matrices have values near one; singular matrices give +Inf. The result is rounded according
+// to opts.
+func ConditionNumber(matrix [][]float64, opts ...Option) (float64, error) {
+	const op = "ConditionNumber"
+	c, err := newConfig(op, opts)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := checkColumns(op, matrix); err != nil {
+		return 0, err
+	}
+	if err := c.checkNaN(op, matrix...); err != nil {
+		return 0, err
+	}
+
+	s, err := singularValues(op, matrix)
+	if err != nil {
+		return 0, err
+	}
+	smallest := s[len(s)-1]
+	cond := []float64{math.Inf(1)}
+	if smallest > 0 {
+		cond[0] = s[0] / smallest
+	}
+
+	// Apply rounding if precision is non-negative
+	c.round(op, matrixShapes(matrix), cond)
+
+	return cond[0], nil
+}
+
+// rank counts the singular values s of an m×n matrix above the call's tolerance, which defaults
+// to max(m, n)·ε·σ_max.
+func (c *config) rank(s []float64, m, n int) int {
+	if len(s) == 0 {
+		return 0
+	}
+	tolerance := c.toleranceOr(float64(max(m, n)) * epsilon * s[0])
+	rank := 0
+	for _, value := range s {
+		if value > tolerance {
+			rank++
+		}
+	}
+	return rank
+}
+
+// checkColumns checks that matrix is rectangular with at least one column, which Gonum needs,
+// returning the number of columns.
+func checkColumns(op string, matrix [][]float64) (int, error) {
+	n, err := checkRectangular(op, matrix)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, emptyError(op, matrixShapes(matrix), "matrix cannot be empty")
+	}
+	return n, nil
+}
+
+// singularValues returns the singular values of a rectangular matrix in decreasing order.
+func singularValues(op string, matrix [][]float64) ([]float64, error) {
+	factors, err := factorSVD(op, matrix, mat.SVDNone)
+	if err != nil {
+		return nil, err
+	}
+	return factors.Values(nil), nil
+}
+
+// factorSVD computes the singular value decomposition of a rectangular matrix with Gonum.
+func factorSVD(op string, matrix [][]float64, kind mat.SVDKind) (*mat.SVD, error) {
+	var factors mat.SVD
+	if !factors.Factorize(denseOf(matrix), kind) {
+		return nil, &ConvergenceError{Op: op, Residual: math.NaN(), msg: "failed to compute the singular value decomposition"}
+	}
+	return &factors, nil
+}
+
+// denseOf copies a rectangular matrix into a Gonum Dense matrix.
+func denseOf(matrix [][]float64) *mat.Dense {
+	dense := mat.NewDense(len(matrix), len(matrix[0]), nil)
+	for i, row := range matrix {
+		dense.SetRow(i, row)
+	}
+	return dense
+}
+
+// rowsOfDense returns the rows of a Gonum Dense matrix as slices of its backing buffer.
+func rowsOfDense(dense *mat.Dense) [][]float64 {
+	raw := dense.RawMatrix()
+	rows := make([][]float64, raw.Rows)
+	for i := range rows {
+		rows[i] = raw.Data[i*raw.Stride : i*raw.Stride+raw.Cols : i*raw.Stride+raw.Cols]
+	}
+	return rows
+}
@@ -0,0 +1,141 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// reconstructSVD returns U·diag(s)·Vᵀ.
+func reconstructSVD(u [][]float64, s []float64, v [][]float64) [][]float64 {
+	scaled := newMatrix(len(u), len(v))
+	for i := range u {
+		for j := range v {
+			for k := range s {
+				scaled[i][j] += u[i][k] * s[k] * v[j][k]
+			}
+		}
+	}
+	return scaled
+}
+
+func TestSVD(t *testing.T) {
+	matrix := [][]float64{{3, 2, 2}, {2, 3, -2}}
+
+	// Test case 1: Full decomposition
+	u, s, v, err := SVD(matrix)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(u) != 2 || len(u[0]) != 2 || len(v) != 3 || len(v[0]) != 3 {
+		t.Errorf("Expected U 2x2 and V 3x3, got %v and %v", u, v)
+	}
+	if !compareSlices(s, []float64{5, 3}, 1e-12) {
+		t.Errorf("Expected singular values [5 3], got %v", s)
+	}
+	if !compareMatrices(reconstructSVD(u, s, v), matrix, 1e-12) {
+		t.Errorf("Expected U·diag(s)·Vᵀ = A, got %v", reconstructSVD(u, s, v))
+	}
+	if !compareMatrices(multiplyMatrices(transposeMatrix(v), v), identityMatrix(3), 1e-12) {
+		t.Errorf("Expected V to be orthogonal, got %v", v)
+	}
+
+	// Test case 2: Thin decomposition
+	u, s, v, err = SVDThin(transposeMatrix(matrix), WithPrecision(8))
+	if err != nil || len(u) != 3 || len(u[0]) != 2 || len(v) != 2 || len(v[0]) != 2 {
+		t.Errorf("Expected U 3x2 and V 2x2, got %v and %v (err %v)", u, v, err)
+	}
+	if !compareSlices(s, []float64{5, 3}, 0) {
+		t.Errorf("Expected rounded singular values [5 3], got %v", s)
+	}
+
+	// Test case 3: Empty matrix
+	_, _, _, err = SVD(nil)
+	if !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestPseudoInverse(t *testing.T) {
+	// Test case 1: Invertible matrices give the inverse
+	pinv, err := PseudoInverse([][]float64{{2, 3}, {4, 5}})
+	if err != nil || !compareMatrices(pinv, [][]float64{{-2.5, 1.5}, {2, -1}}, 1e-12) {
+		t.Errorf("Expected [[-2.5 1.5] [2 -1]], got %v (err %v)", pinv, err)
+	}
+
+	// Test case 2: Singular matrices still give the minimum-norm inverse
+	pinv, err = PseudoInverse([][]float64{{1, 2}, {2, 4}})
+	if err != nil || !compareMatrices(pinv, [][]float64{{0.04, 0.08}, {0.08, 0.16}}, 1e-12) {
+		t.Errorf("Expected [[0.04 0.08] [0.08 0.16]], got %v (err %v)", pinv, err)
+	}
+
+	// Test case 3: Rectangular matrices satisfy A·A⁺·A = A
+	matrix := [][]float64{{1, 2}, {3, 4}, {5, 6}}
+	pinv, err = PseudoInverse(matrix)
+	if err != nil || len(pinv) != 2 || len(pinv[0]) != 3 {
+		t.Fatalf("Expected a 2x3 result, got %v (err %v)", pinv, err)
+	}
+	if !compareMatrices(multiplyMatrices(multiplyMatrices(matrix, pinv), matrix), matrix, 1e-12) {
+		t.Errorf("Expected A·A⁺·A = A, got %v", multiplyMatrices(multiplyMatrices(matrix, pinv), matrix))
+	}
+
+	// Test case 4: A large tolerance drops small singular values
+	pinv, err = PseudoInverse([][]float64{{1, 0}, {0, 1e-9}}, WithTolerance(1e-6))
+	if err != nil || !compareMatrices(pinv, [][]float64{{1, 0}, {0, 0}}, 0) {
+		t.Errorf("Expected [[1 0] [0 0]], got %v (err %v)", pinv, err)
+	}
+}
+
+func TestMatrixRankAndConditionNumber(t *testing.T) {
+	nearlySingular := [][]float64{{1, 1}, {1, 1 + 1e-10}}
+
+	// Test case 1: Rank with the default tolerance
+	rank, err := MatrixRank([][]float64{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}})
+	if err != nil || rank != 2 {
+		t.Errorf("Expected rank 2, got %v (err %v)", rank, err)
+	}
+
+	// Test case 2: Rank with a configured tolerance
+	rank, _ = MatrixRank(nearlySingular)
+	coarse, _ := MatrixRank(nearlySingular, WithTolerance(1e-6))
+	if rank != 2 || coarse != 1 {
+		t.Errorf("Expected ranks 2 and 1, got %v and %v", rank, coarse)
+	}
+
+	// Test case 3: Condition numbers
+	cond, err := ConditionNumber([][]float64{{2, 0}, {0, 0.5}})
+	if err != nil || cond != 4 {
+		t.Errorf("Expected 4, got %v (err %v)", cond, err)
+	}
+	cond, _ = ConditionNumber(nearlySingular)
+	if cond < 1e9 {
+		t.Errorf("Expected a huge condition number, got %v", cond)
+	}
+	cond, _ = ConditionNumber([][]float64{{1, 2}, {2, 4}})
+	if !math.IsInf(cond, 1) && cond < 1e15 {
+		t.Errorf("Expected an infinite or huge condition number, got %v", cond)
+	}
+
+	// Test case 4: Invalid tolerance
+	_, err = MatrixRank(nearlySingular, WithTolerance(-1))
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	// Test case 5: Matrices without columns are rejected rather than handed to Gonum
+	noColumns := [][]float64{{}, {}}
+	if _, _, _, err := SVD(noColumns); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("SVD: expected ErrEmptyInput, got %v", err)
+	}
+	if _, _, _, err := SVDThin(noColumns); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("SVDThin: expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := PseudoInverse(noColumns); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("PseudoInverse: expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := MatrixRank(noColumns); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("MatrixRank: expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := ConditionNumber(noColumns); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("ConditionNumber: expected ErrEmptyInput, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package litearray
+
+import (
+	"math"
+	"sort"
+)
+
+// WeightKind says what the weights given with WithWeights mean. The two kinds agree on weighted
+// means, medians and quantiles but correct for degrees of freedom differently.
+type WeightKind int
+
+const (
+	// FrequencyWeights count repeated observations: a weight of 3 stands for three identical
+	// observations, and the sample size is the sum of the weights. They match NumPy's fweights.
+	FrequencyWeights WeightKind = iota
+	// ReliabilityWeights measure the relative precision of each observation, such as inverse
+	// variances. Only their ratios matter, and the sample size is the effective (Σw)²/Σw². They
+	// match NumPy's aweights.
+	ReliabilityWeights
+)
+
+// weightedOps names the operations that accept WithWeights.
+var weightedOps = map[string]bool{
+	"Mean":                true,
+	"Variance":            true,
+	"StandardDeviation":   true,
+	"Median":              true,
+	"Percentile":          true,
+	"Quantiles":           true,
+	"Covariance":          true,
+	"CorrelationMatrix":   true,
+	"SpearmanCorrelation": true,
+}
+
+// checkWeights checks that weights has one finite, non-negative weight per observation and that
+// they do not all vanish.
+func checkWeights(op string, weights []float64, n int) error {
+	if len(weights) != n {
+		return shapeError(op, [][]int{{len(weights)}, {n}}, "got %d weights for %d observations", len(weights), n)
+	}
+	sum := 0.0
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return valueError(op, ErrDomain, i, w, "weight at index %d must be finite and non-negative, got %v", i, w)
+		}
+		sum += w
+	}
+	if sum == 0 {
+		return valueError(op, ErrDivideByZero, -1, 0, "weights sum to zero")
+	}
+	return nil
+}
+
+// weightedDenominator returns the divisor of a weighted sum of squared deviations for the
+// call's ddof: Σw − ddof for frequency weights and Σw − ddof·Σw²/Σw for reliability weights.
+// Without weights it is n − ddof.
+func (c *config) weightedDenominator(weights []float64, n int) float64 {
+	if weights == nil {
+		return float64(n - c.ddof)
+	}
+	sum, squares := 0.0, 0.0
+	for _, w := range weights {
+		sum += w
+		squares += w * w
+	}
+	if c.weightKind == ReliabilityWeights {
+		return sum - float64(c.ddof)*squares/sum
+	}
+	return sum - float64(c.ddof)
+}
+
+// checkQuantileWeights checks the call's weights for a weighted quantile of n observations, and
+// that the quantile method is one defined by the weighted empirical CDF.
+func (c *config) checkQuantileWeights(op string, n int) error {
+	if err := checkWeights(op, c.weights, n); err != nil {
+		return err
+	}
+	if c.quantileMethod != QuantileInvertedCDF && c.quantileMethod != QuantileAveragedInvertedCDF {
+		return argumentError(op, "weighted quantiles support only QuantileInvertedCDF and QuantileAveragedInvertedCDF, got method %d", int(c.quantileMethod))
+	}
+	return nil
+}
+
+// weightedQuantiles writes the weighted quantiles q of data to dst: the smallest value at which the
+// cumulative weight reaches q·Σw, averaged with the next value when it reaches it exactly under
+// QuantileAveragedInvertedCDF. With unit weights this is the unweighted quantile by the same
+// method. Values of zero weight are ignored. data and weights are not modified.
+func (c *config) weightedQuantiles(dst, data, weights, q []float64) {
+	// Order the values of positive weight, dropping NaN under NaNOmit
+	order := make([]int, 0, len(data))
+	for i, v := range data {
+		if math.IsNaN(v) && !c.omitNaN() {
+			order = order[:0]
+			break
+		}
+		if !math.IsNaN(v) && weights[i] > 0 {
+			order = append(order, i)
+		}
+	}
+	if len(order) == 0 {
+		for i := range dst {
+			dst[i] = math.NaN()
+		}
+		return
+	}
+	sort.Slice(order, func(a, b int) bool { return data[order[a]] < data[order[b]] })
+
+	cumulative := make([]float64, len(order))
+	sum := 0.0
+	for k, i := range order {
+		sum += weights[i]
+		cumulative[k] = sum
+	}
+	for i, p := range q {
+		target := p * sum
+		k := min(sort.SearchFloat64s(cumulative, target), len(order)-1)
+		dst[i] = data[order[k]]
+		if c.quantileMethod == QuantileAveragedInvertedCDF && cumulative[k] == target && k+1 < len(order) {
+			dst[i] = interpolate(dst[i], data[order[k+1]], 0.5)
+		}
+	}
+}
+
+// positionwiseWeighted is positionwise for weighted statistics: fn also receives the weights of
+// the values at each position, one per array. Positions left without weight are NaN.
+func (c *config) positionwiseWeighted(dst []float64, arrays [][]float64, weights []float64, fn func(values, weights []float64) float64) {
+	c.parallel(len(dst), func(lo, hi int) {
+		values, laneWeights := make([]float64, 0, len(arrays)), make([]float64, 0, len(arrays))
+		for i := lo; i < hi; i++ {
+			values, laneWeights = values[:0], laneWeights[:0]
+			total := 0.0
+			for k, array := range arrays {
+				if c.omitNaN() && math.IsNaN(array[i]) {
+					continue
+				}
+				values = append(values, array[i])
+				laneWeights = append(laneWeights, weights[k])
+				total += weights[k]
+			}
+			if total == 0 {
+				dst[i] = math.NaN()
+				continue
+			}
+			dst[i] = fn(values, laneWeights)
+		}
+	})
+}
+
+// weightedLaneMean calculates the weighted mean of a lane with positive total weight.
+func weightedLaneMean(lane, weights []float64) float64 {
+	sum, total := 0.0, 0.0
+	for i, v := range lane {
+		sum += weights[i] * v
+		total += weights[i]
+	}
+	return sum / total
+}
+
+// weightedLaneVariance calculates the weighted variance of a lane with positive total weight,
+// dividing by the weighted denominator for the call's ddof. It uses West's weighted form of
+// Welford's algorithm. Lanes whose denominator is not positive give NaN.
+func (c *config) weightedLaneVariance(lane, weights []float64) float64 {
+	denominator := c.weightedDenominator(weights, len(lane))
+	if !(denominator > 0) {
+		return math.NaN()
+	}
+	mean, squares, total := 0.0, 0.0, 0.0
+	for i, v := range lane {
+		w := weights[i]
+		if w == 0 {
+			continue
+		}
+		total += w
+		delta := v - mean
+		mean += w / total * delta
+		squares += w * delta * (v - mean)
+	}
+	return squares / denominator
+}
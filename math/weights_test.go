@@ -0,0 +1,137 @@
+package litearray
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestWeightedMeanAndVariance(t *testing.T) {
+	arrays := [][]float64{{1, 10}, {2, 20}, {3, 40}}
+
+	// Test case 1: Weighted means, which do not depend on the kind of weights
+	for _, kind := range []WeightKind{FrequencyWeights, ReliabilityWeights} {
+		result, err := Mean(arrays, WithWeights([]float64{1, 2, 1}, kind))
+		if err != nil || !compareSlices(result, []float64{2, 22.5}, 1e-12) {
+			t.Errorf("Kind %d: expected [2 22.5], got %v (err %v)", kind, result, err)
+		}
+	}
+
+	// Test case 2: Frequency weights count repeated arrays
+	weighted, err := Variance(arrays, WithWeights([]float64{1, 2, 1}, FrequencyWeights), WithDDOF(1))
+	repeated, _ := Variance([][]float64{{1, 10}, {2, 20}, {2, 20}, {3, 40}}, WithDDOF(1))
+	if err != nil || !compareSlices(weighted, repeated, 1e-12) {
+		t.Errorf("Expected %v, got %v (err %v)", repeated, weighted, err)
+	}
+
+	// Test case 3: Reliability weights only matter through their ratios
+	weighted, _ = Variance(arrays, WithWeights([]float64{5, 5, 5}, ReliabilityWeights), WithDDOF(1))
+	unweighted, _ := Variance(arrays, WithDDOF(1))
+	if !compareSlices(weighted, unweighted, 1e-12) {
+		t.Errorf("Expected %v, got %v", unweighted, weighted)
+	}
+	deviation, _ := StandardDeviation(arrays, WithWeights([]float64{1, 0, 1}, ReliabilityWeights), WithDDOF(1))
+	if !compareSlices(deviation, []float64{math.Sqrt(2), math.Sqrt(450)}, 1e-12) {
+		t.Errorf("Expected [√2 √450], got %v", deviation)
+	}
+
+	// Test case 4: NaN values are dropped together with their weights
+	result, _ := Mean([][]float64{{1, math.NaN()}, {3, 4}, {5, 6}}, WithWeights([]float64{4, 1, 0}, FrequencyWeights), WithNaNPolicy(NaNOmit))
+	if !compareSlices(result, []float64{1.4, 4}, 1e-12) {
+		t.Errorf("Expected [1.4 4], got %v", result)
+	}
+
+	// Test case 5: Invalid weights
+	var valueErr *ValueError
+	if _, err := Mean(arrays, WithWeights([]float64{1, -2, 1}, FrequencyWeights)); !errors.Is(err, ErrDomain) || !errors.As(err, &valueErr) || valueErr.Index != 1 {
+		t.Errorf("Expected ErrDomain at index 1, got %v", err)
+	}
+	if _, err := Mean(arrays, WithWeights([]float64{1, math.NaN(), 1}, FrequencyWeights)); !errors.Is(err, ErrDomain) {
+		t.Errorf("Expected ErrDomain, got %v", err)
+	}
+	if _, err := Variance(arrays, WithWeights([]float64{1, 2}, FrequencyWeights)); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := Mean(arrays, WithWeights([]float64{0, 0, 0}, ReliabilityWeights)); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v", err)
+	}
+	if _, err := Variance(arrays, WithWeights([]float64{0, 1, 0}, FrequencyWeights), WithDDOF(1)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := Mean(arrays, WithWeights([]float64{1, 1, 1}, ReliabilityWeights+1)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestWeightedQuantiles(t *testing.T) {
+	values := []float64{7, 1, 3, 10, 4, 2}
+	q := []float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 1}
+
+	// Test case 1: Unit weights reproduce the unweighted quantiles of both methods
+	for _, method := range []QuantileMethod{QuantileInvertedCDF, QuantileAveragedInvertedCDF} {
+		weighted, err := Quantiles(values, q, WithWeights([]float64{1, 1, 1, 1, 1, 1}, FrequencyWeights), WithQuantileMethod(method))
+		unweighted, _ := Quantiles(values, q, WithQuantileMethod(method))
+		if err != nil || !compareSlices(weighted, unweighted, 0) {
+			t.Errorf("Method %d: expected %v, got %v (err %v)", method, unweighted, weighted, err)
+		}
+	}
+
+	// Test case 2: Integer frequency weights match repeated values, and zero weights drop them
+	weighted, _ := Quantiles(values, q, WithWeights([]float64{2, 1, 0, 3, 1, 1}, FrequencyWeights))
+	repeated, _ := Quantiles([]float64{7, 7, 1, 10, 10, 10, 4, 2}, q, WithQuantileMethod(QuantileAveragedInvertedCDF))
+	if !compareSlices(weighted, repeated, 0) {
+		t.Errorf("Expected %v, got %v", repeated, weighted)
+	}
+
+	// Test case 3: Weighted medians, averaging where the cumulative weight splits exactly in half
+	median, err := Median([][]float64{{1, 2}, {3, 4}}, WithWeights([]float64{1, 1}, ReliabilityWeights))
+	if err != nil || !compareSlices(median, []float64{2.5}, 0) {
+		t.Errorf("Expected [2.5], got %v (err %v)", median, err)
+	}
+	median, _ = Median([][]float64{{1, 2}, {3, 4}}, WithWeights([]float64{1, 3}, ReliabilityWeights))
+	if !compareSlices(median, []float64{3}, 0) {
+		t.Errorf("Expected [3], got %v", median)
+	}
+	percentiles, err := Percentile(50, [][]float64{{1, 2, 3, 4}, {8, 6, 4, 2}}, WithWeights([]float64{1, 1, 1, 3}, FrequencyWeights))
+	if err != nil || !compareSlices(percentiles, []float64{3.5, 3}, 0) {
+		t.Errorf("Expected [3.5 3], got %v (err %v)", percentiles, err)
+	}
+
+	// Test case 4: NaN handling and invalid input
+	result, _ := Quantiles([]float64{1, math.NaN(), 3}, []float64{0.5}, WithWeights([]float64{1, 1, 1}, FrequencyWeights))
+	if !math.IsNaN(result[0]) {
+		t.Errorf("Expected NaN, got %v", result)
+	}
+	result, _ = Quantiles([]float64{1, math.NaN(), 3}, []float64{0.5}, WithWeights([]float64{1, 1, 3}, FrequencyWeights), WithNaNPolicy(NaNOmit))
+	if !compareSlices(result, []float64{3}, 0) {
+		t.Errorf("Expected [3], got %v", result)
+	}
+	if _, err := Quantiles(values, q, WithWeights([]float64{1, 1, 1, 1, 1, 1}, FrequencyWeights), WithQuantileMethod(QuantileLinear)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := Percentile(50, [][]float64{{1, 2}}, WithWeights([]float64{1}, FrequencyWeights)); !errors.Is(err, ErrShapeMismatch) {
+		t.Errorf("Expected ErrShapeMismatch, got %v", err)
+	}
+	if _, err := Median([][]float64{{1, 2}, {3, 4}}, WithWeights([]float64{0, 0}, FrequencyWeights)); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Expected ErrDivideByZero, got %v", err)
+	}
+}
+
+func TestUnsupportedWeights(t *testing.T) {
+	weights := WithWeights([]float64{1, 2}, FrequencyWeights)
+	arrays := [][]float64{{1, 2}, {3, 4}}
+
+	// Test case 1: Functions without weighted forms reject weights rather than ignore them
+	for _, f := range []func([][]float64, ...Option) ([]float64, error){Skewness, GeometricMean, Min} {
+		if _, err := f(arrays, weights); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Expected ErrInvalidArgument, got %v", err)
+		}
+	}
+	if _, err := KendallCorrelation(arrays, weights); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	arr, _ := NewArray([]float64{1, 2, 3, 4}, 2, 2)
+	if _, err := arr.MeanWith(0, false, weights); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}